@@ -0,0 +1,240 @@
+package elestio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AlertParameter is the metric an AlertRule watches.
+type AlertParameter string
+
+const (
+	AlertParameterCPU        AlertParameter = "CPU"
+	AlertParameterMemory     AlertParameter = "MEMORY"
+	AlertParameterSwap       AlertParameter = "SWAP"
+	AlertParameterSpace      AlertParameter = "SPACE"
+	AlertParameterInode      AlertParameter = "INODE"
+	AlertParameterReadRate   AlertParameter = "READ_RATE"
+	AlertParameterWriteRate  AlertParameter = "WRITE_RATE"
+	AlertParameterSaturation AlertParameter = "SATURATION"
+	AlertParameterDownload   AlertParameter = "DOWNLOAD"
+	AlertParameterUpload     AlertParameter = "UPLOAD"
+)
+
+const (
+	AlertUnitPercent         = "%"
+	AlertUnitMegabytesPerSec = "MB/s"
+)
+
+// alertParameterUnits is the unit of the value of each parameter.
+var alertParameterUnits = map[AlertParameter]string{
+	AlertParameterCPU:        AlertUnitPercent,
+	AlertParameterMemory:     AlertUnitPercent,
+	AlertParameterSwap:       AlertUnitPercent,
+	AlertParameterSpace:      AlertUnitPercent,
+	AlertParameterInode:      AlertUnitPercent,
+	AlertParameterReadRate:   AlertUnitMegabytesPerSec,
+	AlertParameterWriteRate:  AlertUnitMegabytesPerSec,
+	AlertParameterSaturation: AlertUnitPercent,
+	AlertParameterDownload:   AlertUnitMegabytesPerSec,
+	AlertParameterUpload:     AlertUnitMegabytesPerSec,
+}
+
+// AlertRule triggers an alert when Parameter stays above Value for Cycles
+// monitoring cycles. Unit defaults to the unit of the parameter.
+type AlertRule struct {
+	Parameter AlertParameter `json:"parameter"`
+	Value     int64          `json:"value"`
+	Cycles    int64          `json:"cycles"`
+	Unit      string         `json:"unit"`
+}
+
+// ServiceAlerts is the alerting configuration of a service.
+type ServiceAlerts struct {
+	// Cycle is the duration of a monitoring cycle.
+	Cycle time.Duration
+	Rules []AlertRule
+}
+
+// DefaultAlertCycle is the monitoring cycle used by EnableAlerts.
+const DefaultAlertCycle = 60 * time.Second
+
+// DefaultAlertRules returns the rules used by EnableAlerts, a new slice on
+// every call so that callers can change it.
+func DefaultAlertRules() []AlertRule {
+	return []AlertRule{
+		{Parameter: AlertParameterCPU, Value: 90, Cycles: 15, Unit: AlertUnitPercent},
+		{Parameter: AlertParameterMemory, Value: 90, Cycles: 15, Unit: AlertUnitPercent},
+		{Parameter: AlertParameterSwap, Value: 75, Cycles: 15, Unit: AlertUnitPercent},
+		{Parameter: AlertParameterSpace, Value: 80, Cycles: 15, Unit: AlertUnitPercent},
+		{Parameter: AlertParameterInode, Value: 80, Cycles: 15, Unit: AlertUnitPercent},
+		{Parameter: AlertParameterReadRate, Value: 20, Cycles: 15, Unit: AlertUnitMegabytesPerSec},
+		{Parameter: AlertParameterWriteRate, Value: 20, Cycles: 15, Unit: AlertUnitMegabytesPerSec},
+		{Parameter: AlertParameterSaturation, Value: 90, Cycles: 15, Unit: AlertUnitPercent},
+		{Parameter: AlertParameterDownload, Value: 25, Cycles: 15, Unit: AlertUnitMegabytesPerSec},
+		{Parameter: AlertParameterUpload, Value: 25, Cycles: 15, Unit: AlertUnitMegabytesPerSec},
+	}
+}
+
+// Validate checks the parameter, the value, the cycles and the unit of the rule.
+func (r AlertRule) Validate() error {
+	unit, ok := alertParameterUnits[r.Parameter]
+	if !ok {
+		return fmt.Errorf("invalid alert parameter '%s'", r.Parameter)
+	}
+
+	var errs []error
+	if r.Value <= 0 {
+		errs = append(errs, fmt.Errorf("invalid value %d for %s, must be positive", r.Value, r.Parameter))
+	}
+	if r.Cycles <= 0 {
+		errs = append(errs, fmt.Errorf("invalid cycles %d for %s, must be positive", r.Cycles, r.Parameter))
+	}
+	if r.Unit != "" && r.Unit != unit {
+		errs = append(errs, fmt.Errorf("invalid unit '%s' for %s, must be '%s'", r.Unit, r.Parameter, unit))
+	}
+	if unit == AlertUnitPercent && r.Value > 100 {
+		errs = append(errs, fmt.Errorf("invalid value %d for %s, must be at most 100", r.Value, r.Parameter))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EnableAlertsWithRules is like EnableAlerts but with the given monitoring cycle and rules.
+func (h *ServiceHandler) EnableAlertsWithRules(serviceId string, cycle time.Duration, rules []AlertRule) error {
+	return h.EnableAlertsWithRulesCtx(context.Background(), serviceId, cycle, rules)
+}
+
+// EnableAlertsWithRulesCtx is like EnableAlertsWithRules but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableAlertsWithRulesCtx(ctx context.Context, serviceId string, cycle time.Duration, rules []AlertRule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableAlertsWithRules")
+	defer span.end(&err)
+
+	return h.enableAlerts(ctx, serviceId, cycle, rules)
+}
+
+// UpdateAlertRules replaces the monitoring cycle and the rules of a service
+// whose alerts are enabled.
+func (h *ServiceHandler) UpdateAlertRules(service *Service, cycle time.Duration, rules []AlertRule) error {
+	return h.UpdateAlertRulesCtx(context.Background(), service, cycle, rules)
+}
+
+// UpdateAlertRulesCtx is like UpdateAlertRules but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateAlertRulesCtx(ctx context.Context, service *Service, cycle time.Duration, rules []AlertRule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateAlertRules")
+	defer span.end(&err)
+
+	if service.AlertsEnabled == 0 {
+		return fmt.Errorf("alerts are disabled on service %s", service.ID)
+	}
+
+	return h.enableAlerts(ctx, service.ID, cycle, rules)
+}
+
+// GetAlertRules returns the alerting configuration of a service.
+func (h *ServiceHandler) GetAlertRules(serviceId string) (*ServiceAlerts, error) {
+	return h.GetAlertRulesCtx(context.Background(), serviceId)
+}
+
+// GetAlertRulesCtx is like GetAlertRules but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetAlertRulesCtx(ctx context.Context, serviceId string) (_ *ServiceAlerts, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetAlertRules")
+	defer span.end(&err)
+
+	req := struct {
+		ServiceID string `json:"vmID"`
+		Action    string `json:"action"`
+	}{
+		ServiceID: serviceId,
+		Action:    "getAlerts",
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		APIResponse
+		Data struct {
+			MonitCycleInSeconds int64           `json:"monitCycleInSeconds"`
+			Rules               json.RawMessage `json:"rules"`
+		} `json:"data"`
+	}
+	if err = checkAPIResponse(bts, &res); err != nil {
+		return nil, err
+	}
+
+	// The rules are sent as a JSON string and may be returned the same way
+	rulesJSON := []byte(res.Data.Rules)
+	var s string
+	if err = json.Unmarshal(rulesJSON, &s); err == nil {
+		rulesJSON = []byte(s)
+	}
+
+	rules := []AlertRule{}
+	if len(rulesJSON) > 0 && string(rulesJSON) != "null" {
+		if err = json.Unmarshal(rulesJSON, &rules); err != nil {
+			return nil, fmt.Errorf("failed to decode alert rules: %w", err)
+		}
+	}
+
+	return &ServiceAlerts{
+		Cycle: time.Duration(res.Data.MonitCycleInSeconds) * time.Second,
+		Rules: rules,
+	}, nil
+}
+
+func (h *ServiceHandler) enableAlerts(ctx context.Context, serviceId string, cycle time.Duration, rules []AlertRule) error {
+	if cycle < time.Second {
+		return fmt.Errorf("invalid alert cycle %s, must be at least 1s", cycle)
+	}
+
+	rules = slices.Clone(rules)
+	seen := make(map[AlertParameter]bool, len(rules))
+	var errs []error
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %w", i, err))
+			continue
+		}
+		if seen[rule.Parameter] {
+			errs = append(errs, fmt.Errorf("rule %d: duplicated alert parameter '%s'", i, rule.Parameter))
+		}
+		seen[rule.Parameter] = true
+		if rule.Unit == "" {
+			rules[i].Unit = alertParameterUnits[rule.Parameter]
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid alert rules: %w", err)
+	}
+
+	rulesJSON, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	req := struct {
+		ServiceID           string `json:"vmID"`
+		Action              string `json:"action"`
+		MonitCycleInSeconds int64  `json:"monitCycleInSeconds"`
+		Rules               string `json:"rules"`
+	}{
+		ServiceID:           serviceId,
+		Action:              "enableAlerts",
+		MonitCycleInSeconds: int64(cycle / time.Second),
+		Rules:               string(rulesJSON),
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
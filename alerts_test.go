@@ -0,0 +1,67 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRule_Validate(t *testing.T) {
+	for _, rule := range DefaultAlertRules() {
+		require.NoError(t, rule.Validate())
+	}
+
+	require.EqualError(t, AlertRule{Parameter: "GPU", Value: 1, Cycles: 1}.Validate(), "invalid alert parameter 'GPU'")
+	require.EqualError(t, AlertRule{Parameter: AlertParameterCPU, Value: 120, Cycles: 0, Unit: "MB/s"}.Validate(),
+		"invalid cycles 0 for CPU, must be positive\n"+
+			"invalid unit 'MB/s' for CPU, must be '%'\n"+
+			"invalid value 120 for CPU, must be at most 100")
+}
+
+func TestServiceHandler_EnableAlertsWithRules(t *testing.T) {
+	var req struct {
+		Action              string `json:"action"`
+		MonitCycleInSeconds int64  `json:"monitCycleInSeconds"`
+		Rules               string `json:"rules"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	// The defaults are the rules EnableAlerts always sent
+	require.NoError(t, c.Service.EnableAlerts("1"))
+	require.Equal(t, int64(60), req.MonitCycleInSeconds)
+	require.Equal(t, `[{"parameter":"CPU","value":90,"cycles":15,"unit":"%"},{"parameter":"MEMORY","value":90,"cycles":15,"unit":"%"},{"parameter":"SWAP","value":75,"cycles":15,"unit":"%"},{"parameter":"SPACE","value":80,"cycles":15,"unit":"%"},{"parameter":"INODE","value":80,"cycles":15,"unit":"%"},{"parameter":"READ_RATE","value":20,"cycles":15,"unit":"MB/s"},{"parameter":"WRITE_RATE","value":20,"cycles":15,"unit":"MB/s"},{"parameter":"SATURATION","value":90,"cycles":15,"unit":"%"},{"parameter":"DOWNLOAD","value":25,"cycles":15,"unit":"MB/s"},{"parameter":"UPLOAD","value":25,"cycles":15,"unit":"MB/s"}]`, req.Rules)
+
+	rules := []AlertRule{{Parameter: AlertParameterDownload, Value: 50, Cycles: 5}}
+	require.NoError(t, c.Service.EnableAlertsWithRules("1", 2*time.Minute, rules))
+	require.Equal(t, int64(120), req.MonitCycleInSeconds)
+	require.Equal(t, `[{"parameter":"DOWNLOAD","value":50,"cycles":5,"unit":"MB/s"}]`, req.Rules)
+	require.Empty(t, rules[0].Unit)
+
+	err := c.Service.EnableAlertsWithRules("1", time.Minute, []AlertRule{rules[0], rules[0]})
+	require.EqualError(t, err, "invalid alert rules: rule 1: duplicated alert parameter 'DOWNLOAD'")
+}
+
+func TestServiceHandler_GetAlertRules(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status":"OK","data":{"monitCycleInSeconds":30,"rules":"[{\"parameter\":\"CPU\",\"value\":80,\"cycles\":3,\"unit\":\"%\"}]"}}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	alerts, err := c.Service.GetAlertRules("1")
+	require.NoError(t, err)
+	require.Equal(t, &ServiceAlerts{
+		Cycle: 30 * time.Second,
+		Rules: []AlertRule{{Parameter: AlertParameterCPU, Value: 80, Cycles: 3, Unit: AlertUnitPercent}},
+	}, alerts)
+}
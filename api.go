@@ -2,11 +2,14 @@ package elestio
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	neturl "net/url"
+	"time"
 )
 
 type (
@@ -31,90 +34,86 @@ func checkAPIResponse(bts []byte, r any) error {
 	return nil
 }
 
-func (c *Client) sendGetRequest(endpoint string, req interface{}) ([]byte, error) {
-	return c.sendRequest("GET", endpoint, req)
+func (c *Client) sendGetRequest(ctx context.Context, endpoint string, req interface{}) ([]byte, error) {
+	return c.sendRequest(ctx, "GET", endpoint, req)
 }
 
-func (c *Client) sendPutRequest(endpoint string, req interface{}) ([]byte, error) {
-	return c.sendRequest("PUT", endpoint, req)
+func (c *Client) sendPutRequest(ctx context.Context, endpoint string, req interface{}) ([]byte, error) {
+	return c.sendRequest(ctx, "PUT", endpoint, req)
 }
 
-func (c *Client) sendPostRequest(endpoint string, req interface{}) ([]byte, error) {
-	return c.sendRequest("POST", endpoint, req)
+func (c *Client) sendPostRequest(ctx context.Context, endpoint string, req interface{}) ([]byte, error) {
+	return c.sendRequest(ctx, "POST", endpoint, req)
 }
 
-func (c *Client) sendPostRequestRaw(endpoint string, req interface{}) ([]byte, error) {
-	return c.sendRequestRaw("POST", endpoint, req)
+func (c *Client) sendPostRequestRaw(ctx context.Context, endpoint string, req interface{}) ([]byte, error) {
+	return c.sendRequestRaw(ctx, "POST", endpoint, req)
 }
 
-func (c *Client) sendDeleteRequest(endpoint string, req interface{}) ([]byte, error) {
-	return c.sendRequest("DELETE", endpoint, req)
+func (c *Client) sendDeleteRequest(ctx context.Context, endpoint string, req interface{}) ([]byte, error) {
+	return c.sendRequest(ctx, "DELETE", endpoint, req)
 }
 
-func (c *Client) sendRequest(method string, url string, body any) ([]byte, error) {
-	return c.sendRequestCore(method, url, body, true)
+func (c *Client) sendRequest(ctx context.Context, method string, url string, body any) ([]byte, error) {
+	return c.sendRequestCore(ctx, method, url, body, true)
 }
 
-func (c *Client) sendRequestRaw(method string, url string, body any) ([]byte, error) {
-	return c.sendRequestCore(method, url, body, false)
+func (c *Client) sendRequestRaw(ctx context.Context, method string, url string, body any) ([]byte, error) {
+	return c.sendRequestCore(ctx, method, url, body, false)
 }
 
-func (c *Client) sendRequestCore(method string, url string, body any, validateAPIResponse bool) ([]byte, error) {
-	var bts []byte
-	if body != nil {
-		var err error
-		bts, err = json.Marshal(body)
-		if err != nil {
-			return nil, err
-		}
-	}
+func (c *Client) sendRequestCore(ctx context.Context, method string, url string, body any, validateAPIResponse bool) ([]byte, error) {
+	policy := c.retryPolicy()
+	endpoint := endpointPath(url)
 
-	retryCount := 2
+	attempt := 0
+	reauthenticated := false
 	for {
-		req, err := http.NewRequest(method, url, bytes.NewBuffer(bts))
-		if err != nil {
-			return nil, err
-		}
+		attempt++
 
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.jwt))
-
-		// Temporary fix waiting api handle jwt in authoization header
-		query := req.URL.Query()
-		query.Set("jwt", c.jwt)
-		req.URL.RawQuery = query.Encode()
+		// Sign in on the first request when the client was created without eager sign in
+		if c.token() == "" && c.canReauthenticate(url) {
+			if err := c.refreshToken(ctx, ""); err != nil {
+				return nil, fmt.Errorf("failed to sign in: %w", err)
+			}
+		}
+		jwt := c.token()
+		legacyJWT := c.sendsLegacyJWT(endpoint)
 
-		rsp, err := c.HTTPClient.Do(req)
+		bts, err := encodeRequestBody(body, jwt, legacyJWT)
 		if err != nil {
 			return nil, err
 		}
+		op := Operation{Name: OperationName(ctx), Action: requestAction(bts)}
+		idempotent := policy.isIdempotent(method, endpoint, op.Action)
 
-		defer func() {
-			err := rsp.Body.Close()
-			if err != nil {
-				log.Println("Cannot close response body: %w", err)
-			}
-		}()
-
-		responseBody, err := io.ReadAll(rsp.Body)
+		rsp, responseBody, err := c.roundTrip(ctx, op, method, url, bts, attempt, jwt, legacyJWT)
 		if err != nil {
+			// Retry network errors for idempotent requests unless ctx is done
+			if rsp == nil && ctx.Err() == nil && idempotent && policy.canRetry(attempt) {
+				if err := policy.wait(ctx, attempt, nil); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			return nil, err
 		}
 
-		// Retry in case of timeout or error for GET requests
-		if (rsp.StatusCode == 408 || rsp.StatusCode >= 500) && retryCount > 0 && method == "GET" {
-			retryCount--
+		// Retry in case of throttling, timeout or server error
+		if policy.shouldRetryStatus(rsp.StatusCode, idempotent) && policy.canRetry(attempt) {
+			if err := policy.wait(ctx, attempt, rsp); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
-		// Return error if status code is not 2xx
-		if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-			return nil, fmt.Errorf("request failed with status code %d: %s", rsp.StatusCode, string(responseBody))
-		}
+		var apiErr *APIError
 
-		// Validate APIResponse if requested
-		if validateAPIResponse {
+		if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+			// Return error if status code is not 2xx
+			apiErr = newAPIError(url, rsp.StatusCode, responseBody)
+		} else if validateAPIResponse {
+			// Validate APIResponse if requested
 			var res APIResponse
 			if err = checkAPIResponse(responseBody, &res); err != nil {
 				return nil, err
@@ -122,10 +121,155 @@ func (c *Client) sendRequestCore(method string, url string, body any, validateAP
 
 			// Return error if response status is KO
 			if res.Status == "KO" {
-				return nil, fmt.Errorf("request failed with status code %d: %s", rsp.StatusCode, string(res.Message))
+				apiErr = &APIError{
+					HTTPStatusCode: rsp.StatusCode,
+					Status:         res.Status,
+					Message:        res.Message,
+					Endpoint:       url,
+					Body:           responseBody,
+				}
 			}
 		}
 
+		if apiErr != nil {
+			// Sign in again and retry once if the jwt has expired
+			if !reauthenticated && c.canReauthenticate(url) && isExpiredTokenError(apiErr) {
+				reauthenticated = true
+				if err := c.refreshToken(ctx, jwt); err != nil {
+					return nil, fmt.Errorf("failed to refresh jwt: %w", err)
+				}
+				continue
+			}
+
+			return nil, apiErr
+		}
+
 		return responseBody, nil
 	}
 }
+
+// roundTrip sends a single attempt of a request through the client
+// middlewares and returns the response along with its body.
+// The returned response is nil if no response was received.
+func (c *Client) roundTrip(ctx context.Context, op Operation, method, url string, body []byte, attempt int, jwt string, legacyJWT bool) (*http.Response, []byte, error) {
+	endpoint := endpointPath(url)
+
+	ctx, span := c.startRequestSpan(ctx, op, method, endpoint, attempt)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
+	if err != nil {
+		endRequestSpan(span, 0, err)
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	// Temporary fix waiting api handle jwt in authoization header
+	if legacyJWT {
+		query := req.URL.Query()
+		query.Set("jwt", jwt)
+		req.URL.RawQuery = query.Encode()
+	}
+
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		endRequestSpan(span, 0, err)
+		return nil, nil, err
+	}
+	defer release()
+
+	start := time.Now()
+	rsp, err := c.do(op, req)
+	if err != nil {
+		// Keep the jwt query parameter out of errors and logs
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = url
+		}
+		c.logExchange(ctx, op, method, endpoint, attempt, body, 0, nil, err, time.Since(start))
+		endRequestSpan(span, 0, err)
+		return nil, nil, err
+	}
+
+	responseBody, err := io.ReadAll(rsp.Body)
+	if err := rsp.Body.Close(); err != nil {
+		c.logger().Warn("cannot close response body", "error", err)
+	}
+	c.logExchange(ctx, op, method, endpoint, attempt, body, rsp.StatusCode, responseBody, err, time.Since(start))
+	endRequestSpan(span, rsp.StatusCode, err)
+
+	// Slow down every handler of the client when the API throttles us
+	if rsp.StatusCode == http.StatusTooManyRequests && c.limiter != nil {
+		pause, ok := parseRetryAfter(rsp.Header.Get("Retry-After"))
+		if !ok {
+			pause = defaultThrottlePause
+		}
+		// Never block the client longer than a retry would wait
+		if maxBackoff := c.retryPolicy().MaxBackoff; maxBackoff > 0 {
+			pause = min(pause, maxBackoff)
+		}
+		c.limiter.pause(pause)
+	}
+	if err != nil {
+		return rsp, nil, err
+	}
+
+	return rsp, responseBody, nil
+}
+
+// encodeRequestBody marshals body to JSON and, if injectJWT is set and
+// body is a JSON object, adds the jwt field expected by most endpoints.
+// This is the only place where the jwt is written in request bodies.
+func encodeRequestBody(body any, jwt string, injectJWT bool) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	bts, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !injectJWT {
+		return bts, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bts, &fields); err != nil {
+		// Not a JSON object, nothing to inject
+		return bts, nil
+	}
+
+	if fields["jwt"], err = json.Marshal(jwt); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// requestAction returns the DoActionOnServer action of an encoded request body.
+func requestAction(bts []byte) string {
+	var req struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(bts, &req); err != nil {
+		return ""
+	}
+
+	return req.Action
+}
+
+// endpointPath returns the path of an endpoint URL, e.g. "/api/servers/getServices".
+func endpointPath(endpoint string) string {
+	u, err := neturl.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	return u.Path
+}
@@ -0,0 +1,28 @@
+package elestio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendRequest_ContextCanceled(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	c := NewUnsignedClient()
+	c.BaseURL = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.Project.GetListCtx(ctx)
+	require.ErrorIs(t, err, context.Canceled, "expected canceled context error")
+	require.False(t, called, "expected no request to reach the server")
+}
@@ -1,9 +1,34 @@
 package elestio
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
+const signInEndpoint = "/api/auth/checkAPIToken"
+
+// AuthMode controls where the jwt is sent with each request.
+type AuthMode int
+
+const (
+	// AuthModeLegacy sends the jwt in the Authorization header,
+	// the jwt query parameter and the jwt field of the request body.
+	AuthModeLegacy AuthMode = iota
+
+	// AuthModeHeaderOnly sends the jwt only in the Authorization header,
+	// keeping it out of URLs that end up in proxy and access logs.
+	AuthModeHeaderOnly
+)
+
+// headerAuthEndpoints lists the endpoints that read the jwt from the
+// Authorization header, they never receive it in the query or the body.
+// WithHeaderAuthEndpoints adds endpoints to this list for a client.
+var headerAuthEndpoints = map[string]bool{
+	signInEndpoint: true,
+}
+
 type (
 	authRequest struct {
 		Email  string `json:"email"`
@@ -16,8 +41,11 @@ type (
 	}
 )
 
-func (c *Client) signIn() error {
-	bts, err := c.sendPostRequest(fmt.Sprintf("%s/api/auth/checkAPIToken", c.BaseURL), authRequest{c.Email, c.ApiKey})
+func (c *Client) signIn(ctx context.Context) (err error) {
+	ctx, span := c.startOperation(ctx, "Auth.SignIn")
+	defer span.end(&err)
+
+	bts, err := c.sendPostRequest(ctx, fmt.Sprintf("%s%s", c.BaseURL, signInEndpoint), authRequest{c.Email, c.ApiKey})
 	if err != nil {
 		return err
 	}
@@ -27,7 +55,61 @@ func (c *Client) signIn() error {
 		return err
 	}
 
-	c.jwt = r.JWT
+	c.setToken(r.JWT)
 
 	return nil
 }
+
+// token returns the current jwt.
+func (c *Client) token() string {
+	c.jwtMu.RLock()
+	defer c.jwtMu.RUnlock()
+
+	return c.jwt
+}
+
+func (c *Client) setToken(jwt string) {
+	c.jwtMu.Lock()
+	defer c.jwtMu.Unlock()
+
+	c.jwt = jwt
+}
+
+// refreshToken signs in again unless the jwt has already been
+// replaced by a concurrent request since staleToken was used.
+func (c *Client) refreshToken(ctx context.Context, staleToken string) error {
+	c.signInMu.Lock()
+	defer c.signInMu.Unlock()
+
+	if c.token() != staleToken {
+		return nil
+	}
+
+	return c.signIn(ctx)
+}
+
+// canReauthenticate reports whether a request to url can be retried
+// after signing in again.
+func (c *Client) canReauthenticate(url string) bool {
+	if c.Email == "" || c.ApiKey == "" {
+		return false
+	}
+
+	return !strings.HasSuffix(url, signInEndpoint)
+}
+
+// sendsLegacyJWT reports whether the jwt must be copied in the query
+// string and the body of a request to endpoint.
+func (c *Client) sendsLegacyJWT(endpoint string) bool {
+	return c.AuthMode == AuthModeLegacy && !headerAuthEndpoints[endpoint] && !c.headerAuthOnly[endpoint]
+}
+
+// isExpiredTokenError reports whether err means the jwt is expired or invalid.
+func isExpiredTokenError(err *APIError) bool {
+	if err.HTTPStatusCode == http.StatusUnauthorized {
+		return true
+	}
+
+	message := strings.ToLower(err.Message)
+	return err.Status == "KO" && (strings.Contains(message, "jwt") || strings.Contains(message, "token expired") || strings.Contains(message, "invalid token"))
+}
@@ -1,7 +1,11 @@
 package elestio
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,3 +16,71 @@ func TestAuthSignIn(t *testing.T) {
 	require.NoError(t, err, "expected no error")
 	require.NotNil(t, c, "expected non-nil client")
 }
+
+func TestAuthRefreshOnUnauthorized(t *testing.T) {
+	var signIns atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case signInEndpoint:
+			signIns.Add(1)
+			_, _ = w.Write([]byte(`{"status":"OK","jwt":"fresh"}`))
+		case "/api/projects/getList":
+			var body struct {
+				JWT string `json:"jwt"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body.JWT != "fresh" || r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"projects":[{"id":1}]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewUnsignedClient()
+	c.BaseURL = srv.URL
+	c.Email, c.ApiKey = "user@example.com", "key"
+	c.setToken("expired")
+
+	projects, err := c.Project.GetList()
+	require.NoError(t, err, "expected request to succeed after refreshing the jwt")
+	require.Len(t, *projects, 1)
+	require.Equal(t, int32(1), signIns.Load(), "expected exactly one sign in")
+	require.Equal(t, "fresh", c.token())
+}
+
+func TestAuthModeHeaderOnly(t *testing.T) {
+	for _, tc := range []struct {
+		opts     []Option
+		inQuery  bool
+		inBody   bool
+		testName string
+	}{
+		{[]Option{WithAuthMode(AuthModeLegacy)}, true, true, "legacy"},
+		{[]Option{WithAuthMode(AuthModeHeaderOnly)}, false, false, "header only"},
+		{[]Option{WithHeaderAuthEndpoints("/api/servers/DoActionOnServer")}, false, false, "header only endpoint"},
+		{[]Option{WithHeaderAuthEndpoints("/api/servers/getServerDetails")}, true, true, "other header only endpoint"},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+				require.Equal(t, tc.inQuery, r.URL.Query().Has("jwt"), "unexpected jwt query parameter")
+				_, hasBodyJWT := body["jwt"]
+				require.Equal(t, tc.inBody, hasBodyJWT, "unexpected jwt body field")
+				require.Equal(t, "1", body["vmID"])
+
+				_, _ = w.Write([]byte(`{"status":"OK"}`))
+			}))
+			defer srv.Close()
+
+			c := NewUnsignedClient(append([]Option{WithBaseURL(srv.URL)}, tc.opts...)...)
+			c.setToken("secret")
+
+			require.NoError(t, c.Service.RebootServer("1"))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AutoUpdateSchedule is the weekly time at which a service is updated.
+type AutoUpdateSchedule struct {
+	Weekday time.Weekday
+	Hour    int
+	Minute  int
+	// Location is the time zone of Weekday, Hour and Minute, UTC if nil.
+	// Elestio stores schedules in UTC, the conversion uses the current
+	// offset of the location so it can drift by an hour across DST changes.
+	Location *time.Location
+}
+
+// DefaultAppAutoUpdateSchedule returns the schedule used by EnableAppAutoUpdates.
+func DefaultAppAutoUpdateSchedule() AutoUpdateSchedule {
+	return AutoUpdateSchedule{Weekday: time.Sunday, Hour: 1}
+}
+
+// DefaultSystemAutoUpdateSchedule returns the schedule used by EnableSystemAutoUpdates.
+func DefaultSystemAutoUpdateSchedule() AutoUpdateSchedule {
+	return AutoUpdateSchedule{Weekday: time.Sunday, Hour: 5}
+}
+
+// AutoUpdateKind selects the app or the system auto updates.
+type AutoUpdateKind string
+
+const (
+	AutoUpdateKindApp    AutoUpdateKind = "app"
+	AutoUpdateKindSystem AutoUpdateKind = "system"
+)
+
+// Validate checks that the schedule fields are in range.
+func (s AutoUpdateSchedule) Validate() error {
+	var errs []error
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		errs = append(errs, fmt.Errorf("invalid weekday %d, must be between 0 and 6", s.Weekday))
+	}
+	if s.Hour < 0 || s.Hour > 23 {
+		errs = append(errs, fmt.Errorf("invalid hour %d, must be between 0 and 23", s.Hour))
+	}
+	if s.Minute < 0 || s.Minute > 59 {
+		errs = append(errs, fmt.Errorf("invalid minute %d, must be between 0 and 59", s.Minute))
+	}
+
+	return errors.Join(errs...)
+}
+
+// utc returns the schedule converted to UTC.
+func (s AutoUpdateSchedule) utc() AutoUpdateSchedule {
+	if s.Location == nil || s.Location == time.UTC {
+		return AutoUpdateSchedule{Weekday: s.Weekday, Hour: s.Hour, Minute: s.Minute}
+	}
+
+	now := time.Now().In(s.Location)
+	day := now.AddDate(0, 0, int(s.Weekday)-int(now.Weekday()))
+	t := time.Date(day.Year(), day.Month(), day.Day(), s.Hour, s.Minute, 0, 0, s.Location).UTC()
+
+	return AutoUpdateSchedule{Weekday: t.Weekday(), Hour: t.Hour(), Minute: t.Minute()}
+}
+
+// EnableAppAutoUpdatesWithSchedule is like EnableAppAutoUpdates but runs the updates at schedule.
+func (h *ServiceHandler) EnableAppAutoUpdatesWithSchedule(serviceId string, schedule AutoUpdateSchedule) error {
+	return h.EnableAppAutoUpdatesWithScheduleCtx(context.Background(), serviceId, schedule)
+}
+
+// EnableAppAutoUpdatesWithScheduleCtx is like EnableAppAutoUpdatesWithSchedule but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableAppAutoUpdatesWithScheduleCtx(ctx context.Context, serviceId string, schedule AutoUpdateSchedule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableAppAutoUpdatesWithSchedule")
+	defer span.end(&err)
+
+	return h.enableAppAutoUpdates(ctx, serviceId, schedule)
+}
+
+// EnableSystemAutoUpdatesWithSchedule is like EnableSystemAutoUpdates but reboots the service at schedule.
+func (h *ServiceHandler) EnableSystemAutoUpdatesWithSchedule(serviceId string, isSystemAutoUpdatesSecurityPatchesOnlyEnabled bool, schedule AutoUpdateSchedule) error {
+	return h.EnableSystemAutoUpdatesWithScheduleCtx(context.Background(), serviceId, isSystemAutoUpdatesSecurityPatchesOnlyEnabled, schedule)
+}
+
+// EnableSystemAutoUpdatesWithScheduleCtx is like EnableSystemAutoUpdatesWithSchedule but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableSystemAutoUpdatesWithScheduleCtx(ctx context.Context, serviceId string, isSystemAutoUpdatesSecurityPatchesOnlyEnabled bool, schedule AutoUpdateSchedule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableSystemAutoUpdatesWithSchedule")
+	defer span.end(&err)
+
+	return h.enableSystemAutoUpdates(ctx, serviceId, isSystemAutoUpdatesSecurityPatchesOnlyEnabled, schedule)
+}
+
+// UpdateAutoUpdateSchedule changes the schedule of the app or system auto
+// updates of a service, which must be enabled. The security patches only
+// setting of the system auto updates is kept.
+func (h *ServiceHandler) UpdateAutoUpdateSchedule(service *Service, kind AutoUpdateKind, schedule AutoUpdateSchedule) error {
+	return h.UpdateAutoUpdateScheduleCtx(context.Background(), service, kind, schedule)
+}
+
+// UpdateAutoUpdateScheduleCtx is like UpdateAutoUpdateSchedule but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateAutoUpdateScheduleCtx(ctx context.Context, service *Service, kind AutoUpdateKind, schedule AutoUpdateSchedule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateAutoUpdateSchedule")
+	defer span.end(&err)
+
+	switch kind {
+	case AutoUpdateKindApp:
+		if service.AppAutoUpdatesEnabled == 0 {
+			return fmt.Errorf("app auto updates are disabled on service %s", service.ID)
+		}
+		return h.enableAppAutoUpdates(ctx, service.ID, schedule)
+	case AutoUpdateKindSystem:
+		if service.SystemAutoUpdatesEnabled == 0 {
+			return fmt.Errorf("system auto updates are disabled on service %s", service.ID)
+		}
+		return h.enableSystemAutoUpdates(ctx, service.ID, service.SystemAutoUpdatesSecurityPatchesOnlyEnabled == 1, schedule)
+	default:
+		return fmt.Errorf("invalid auto update kind '%s'", kind)
+	}
+}
+
+func (h *ServiceHandler) enableAppAutoUpdates(ctx context.Context, serviceId string, schedule AutoUpdateSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("invalid app auto update schedule: %w", err)
+	}
+	schedule = schedule.utc()
+
+	req := struct {
+		ServiceID       string `json:"vmID"`
+		Action          string `json:"action"`
+		UpdateDayOfWeek string `json:"appAutoUpdateDayOfWeek"`
+		UpdateHour      string `json:"appAutoUpdateHour"`
+		UpdateMinute    string `json:"appAutoUpdateMinute"`
+	}{
+		ServiceID:       serviceId,
+		Action:          "appAutoUpdateEnable",
+		UpdateDayOfWeek: fmt.Sprintf("%d", schedule.Weekday),
+		UpdateHour:      fmt.Sprintf("%d", schedule.Hour),
+		UpdateMinute:    fmt.Sprintf("%02d", schedule.Minute),
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
+
+func (h *ServiceHandler) enableSystemAutoUpdates(ctx context.Context, serviceId string, isSystemAutoUpdatesSecurityPatchesOnlyEnabled bool, schedule AutoUpdateSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("invalid system auto update schedule: %w", err)
+	}
+	schedule = schedule.utc()
+
+	req := struct {
+		ServiceID                                     string `json:"vmID"`
+		Action                                        string `json:"action"`
+		UpdateDayOfWeek                               string `json:"systemAutoUpdateRebootDayOfWeek"`
+		UpdateHour                                    string `json:"systemAutoUpdateRebootHour"`
+		UpdateMinute                                  string `json:"systemAutoUpdateRebootMinute"`
+		IsSystemAutoUpdatesSecurityPatchesOnlyEnabled bool   `json:"systemAutoUpdateSecurityPatchesOnly"`
+	}{
+		ServiceID:       serviceId,
+		Action:          "systemAutoUpdateEnable",
+		UpdateDayOfWeek: fmt.Sprintf("%d", schedule.Weekday),
+		UpdateHour:      fmt.Sprintf("%d", schedule.Hour),
+		UpdateMinute:    fmt.Sprintf("%02d", schedule.Minute),
+		IsSystemAutoUpdatesSecurityPatchesOnlyEnabled: isSystemAutoUpdatesSecurityPatchesOnlyEnabled,
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
@@ -0,0 +1,50 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoUpdateSchedule_Validate(t *testing.T) {
+	require.NoError(t, DefaultAppAutoUpdateSchedule().Validate())
+
+	err := AutoUpdateSchedule{Weekday: 7, Hour: 24, Minute: -1}.Validate()
+	require.EqualError(t, err, "invalid weekday 7, must be between 0 and 6\n"+
+		"invalid hour 24, must be between 0 and 23\n"+
+		"invalid minute -1, must be between 0 and 59")
+}
+
+func TestAutoUpdateSchedule_UTC(t *testing.T) {
+	// Monday 01:30 at UTC+3 is Sunday 22:30 UTC
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	utc := AutoUpdateSchedule{Weekday: time.Monday, Hour: 1, Minute: 30, Location: loc}.utc()
+	require.Equal(t, AutoUpdateSchedule{Weekday: time.Sunday, Hour: 22, Minute: 30}, utc)
+}
+
+func TestServiceHandler_UpdateAutoUpdateSchedule(t *testing.T) {
+	var req map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	service := &Service{ID: "1", SystemAutoUpdatesEnabled: 1, SystemAutoUpdatesSecurityPatchesOnlyEnabled: 1}
+	err := c.Service.UpdateAutoUpdateSchedule(service, AutoUpdateKindSystem, AutoUpdateSchedule{Weekday: time.Wednesday, Hour: 3, Minute: 5})
+	require.NoError(t, err)
+	require.Equal(t, "systemAutoUpdateEnable", req["action"])
+	require.Equal(t, "3", req["systemAutoUpdateRebootDayOfWeek"])
+	require.Equal(t, "3", req["systemAutoUpdateRebootHour"])
+	require.Equal(t, "05", req["systemAutoUpdateRebootMinute"])
+	require.Equal(t, true, req["systemAutoUpdateSecurityPatchesOnly"])
+
+	err = c.Service.UpdateAutoUpdateSchedule(service, AutoUpdateKindApp, DefaultAppAutoUpdateSchedule())
+	require.EqualError(t, err, "app auto updates are disabled on service 1")
+}
@@ -0,0 +1,275 @@
+package elestio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BackupHandler lists, creates, restores and deletes the snapshots and the
+// remote backups of services.
+type BackupHandler struct {
+	client *Client
+}
+
+// BackupKind is where a backup is stored.
+type BackupKind string
+
+const (
+	// BackupKindSnapshot is a snapshot of the whole service disk, kept by
+	// the provider, see ServiceHandler.EnableBackups.
+	BackupKindSnapshot BackupKind = "snapshot"
+	// BackupKindRemote is a backup of some paths of the service, kept on
+	// the Elestio remote storage, see ServiceHandler.EnableRemoteBackups.
+	BackupKindRemote BackupKind = "remote"
+)
+
+// Backup is a snapshot or a remote backup of a service.
+type Backup struct {
+	ID        string
+	ServiceID string
+	Kind      BackupKind
+	Name      string
+	// CreatedAt is zero if the API returned no date or one in an unknown format.
+	CreatedAt time.Time
+	SizeBytes int64
+	// Path is the location of a remote backup in the remote storage.
+	Path string
+	// Location is the region or datacenter where the backup is stored.
+	Location string
+	// DownloadURL is a link to download the backup, empty if the backup
+	// cannot be downloaded.
+	DownloadURL string
+}
+
+// backupTimeLayouts are the date formats the API uses for backups.
+var backupTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+// backupTime is a backup date, left zero when it cannot be parsed so that
+// one bad entry does not fail the whole list.
+type backupTime time.Time
+
+func (t *backupTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil || s == "" {
+		return nil
+	}
+
+	for _, layout := range backupTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			*t = backupTime(parsed)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// backupResponse is a backup as returned by the API.
+type backupResponse struct {
+	ID          FlexString `json:"id"`
+	Name        string     `json:"name"`
+	CreatedAt   backupTime `json:"date"`
+	SizeBytes   int64      `json:"size"`
+	Path        string     `json:"path"`
+	Location    string     `json:"location"`
+	DownloadURL string     `json:"downloadUrl"`
+}
+
+// List returns the backups of the given kind of a service, most recent
+// first. Backups without a valid date are listed last.
+func (h *BackupHandler) List(serviceId string, kind BackupKind) ([]Backup, error) {
+	return h.ListCtx(context.Background(), serviceId, kind)
+}
+
+// ListCtx is like List but uses ctx for the underlying HTTP requests.
+func (h *BackupHandler) ListCtx(ctx context.Context, serviceId string, kind BackupKind) (_ []Backup, err error) {
+	ctx, span := h.client.startOperation(ctx, "Backup.List")
+	defer span.end(&err)
+
+	var bts []byte
+	switch kind {
+	case BackupKindSnapshot:
+		bts, err = h.doAction(ctx, serviceId, "getSnapshots", nil)
+	case BackupKindRemote:
+		bts, err = h.sendBackupRequest(ctx, "getBackupsList", serviceId, "")
+	default:
+		return nil, fmt.Errorf("invalid backup kind '%s'", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		APIResponse
+		Data []backupResponse `json:"data"`
+	}
+	if err = checkAPIResponse(bts, &res); err != nil {
+		return nil, err
+	}
+
+	backups := make([]Backup, 0, len(res.Data))
+	for _, b := range res.Data {
+		backups = append(backups, Backup{
+			ID:          string(b.ID),
+			ServiceID:   serviceId,
+			Kind:        kind,
+			Name:        b.Name,
+			CreatedAt:   time.Time(b.CreatedAt),
+			SizeBytes:   b.SizeBytes,
+			Path:        b.Path,
+			Location:    b.Location,
+			DownloadURL: b.DownloadURL,
+		})
+	}
+
+	// Most recent first
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// Create triggers an on-demand backup of the service, the backup is
+// listed by List once it completes.
+func (h *BackupHandler) Create(serviceId string, kind BackupKind) error {
+	return h.CreateCtx(context.Background(), serviceId, kind)
+}
+
+// CreateCtx is like Create but uses ctx for the underlying HTTP requests.
+func (h *BackupHandler) CreateCtx(ctx context.Context, serviceId string, kind BackupKind) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Backup.Create")
+	defer span.end(&err)
+
+	var bts []byte
+	switch kind {
+	case BackupKindSnapshot:
+		bts, err = h.doAction(ctx, serviceId, "takeSnapshot", nil)
+	case BackupKindRemote:
+		bts, err = h.sendBackupRequest(ctx, "RunAutoBackupNow", serviceId, "")
+	default:
+		return fmt.Errorf("invalid backup kind '%s'", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
+
+// Restore restores the service of the backup to the state of the backup,
+// overwriting its current data.
+func (h *BackupHandler) Restore(backup Backup) error {
+	return h.RestoreCtx(context.Background(), backup)
+}
+
+// RestoreCtx is like Restore but uses ctx for the underlying HTTP requests.
+func (h *BackupHandler) RestoreCtx(ctx context.Context, backup Backup) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Backup.Restore")
+	defer span.end(&err)
+
+	return h.doOnBackup(ctx, backup, "restoreSnapshot", "RestoreBackup")
+}
+
+// Delete deletes the backup from the provider or the remote storage
+// depending on its kind.
+func (h *BackupHandler) Delete(backup Backup) error {
+	return h.DeleteCtx(context.Background(), backup)
+}
+
+// DeleteCtx is like Delete but uses ctx for the underlying HTTP requests.
+func (h *BackupHandler) DeleteCtx(ctx context.Context, backup Backup) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Backup.Delete")
+	defer span.end(&err)
+
+	return h.doOnBackup(ctx, backup, "deleteSnapshot", "DeleteBackup")
+}
+
+// DeleteOlderThan deletes the backups of the given kind created before
+// cutoff and returns the deleted ones. Backups without a date are kept.
+func (h *BackupHandler) DeleteOlderThan(serviceId string, kind BackupKind, cutoff time.Time) ([]Backup, error) {
+	return h.DeleteOlderThanCtx(context.Background(), serviceId, kind, cutoff)
+}
+
+// DeleteOlderThanCtx is like DeleteOlderThan but uses ctx for the underlying HTTP requests.
+func (h *BackupHandler) DeleteOlderThanCtx(ctx context.Context, serviceId string, kind BackupKind, cutoff time.Time) (_ []Backup, err error) {
+	ctx, span := h.client.startOperation(ctx, "Backup.DeleteOlderThan")
+	defer span.end(&err)
+
+	backups, err := h.ListCtx(ctx, serviceId, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []Backup{}
+	for _, backup := range backups {
+		if backup.CreatedAt.IsZero() || !backup.CreatedAt.Before(cutoff) {
+			continue
+		}
+		if err = h.DeleteCtx(ctx, backup); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", backup.ID, err)
+		}
+		deleted = append(deleted, backup)
+	}
+
+	return deleted, nil
+}
+
+// doOnBackup runs snapshotAction or remoteAction on the backup depending on its kind.
+func (h *BackupHandler) doOnBackup(ctx context.Context, backup Backup, snapshotAction, remoteAction string) error {
+	// Both actions are destructive, never send them for an unknown backup
+	if backup.ID == "" {
+		return errors.New("backup id is required")
+	}
+	if backup.ServiceID == "" {
+		return fmt.Errorf("service id of backup %s is required", backup.ID)
+	}
+
+	var bts []byte
+	var err error
+	switch backup.Kind {
+	case BackupKindSnapshot:
+		bts, err = h.doAction(ctx, backup.ServiceID, snapshotAction, &backup.ID)
+	case BackupKindRemote:
+		bts, err = h.sendBackupRequest(ctx, remoteAction, backup.ServiceID, backup.ID)
+	default:
+		return fmt.Errorf("invalid backup kind '%s'", backup.Kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
+
+// doAction sends a DoActionOnServer request for a snapshot action.
+func (h *BackupHandler) doAction(ctx context.Context, serviceId, action string, snapshotID *string) ([]byte, error) {
+	req := struct {
+		ServiceID  string  `json:"vmID"`
+		Action     string  `json:"action"`
+		SnapshotID *string `json:"snapshotID,omitempty"`
+	}{
+		ServiceID:  serviceId,
+		Action:     action,
+		SnapshotID: snapshotID,
+	}
+
+	return h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+}
+
+// sendBackupRequest sends a request to a remote backups endpoint.
+func (h *BackupHandler) sendBackupRequest(ctx context.Context, endpoint, serviceId, backupID string) ([]byte, error) {
+	req := struct {
+		ServiceID string `json:"serverID"`
+		BackupID  string `json:"backupID,omitempty"`
+	}{
+		ServiceID: serviceId,
+		BackupID:  backupID,
+	}
+
+	return h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/backups/%s", h.client.BaseURL, endpoint), req)
+}
@@ -0,0 +1,118 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupHandler_List(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/servers/DoActionOnServer":
+			_, _ = fmt.Fprint(w, `{"status":"OK","data":[
+				{"id":1,"name":"old","date":"2024-01-01 04:00:00","size":1024},
+				{"id":2,"name":"new","date":"2024-02-01T04:00:00Z","size":2048},
+				{"id":3,"name":"undated","date":"yesterday","size":512}
+			]}`)
+		case "/api/backups/getBackupsList":
+			_, _ = fmt.Fprint(w, `{"status":"OK","data":[{"id":"a","date":"2024-03-01T04:00:00Z","size":10,"path":"/backups/a.tar.gz","location":"eu-central","downloadUrl":"https://backups.example.com/a.tar.gz"}]}`)
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	snapshots, err := c.Backup.List("1", BackupKindSnapshot)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+	require.Equal(t, Backup{
+		ID:        "2",
+		ServiceID: "1",
+		Kind:      BackupKindSnapshot,
+		Name:      "new",
+		CreatedAt: time.Date(2024, 2, 1, 4, 0, 0, 0, time.UTC),
+		SizeBytes: 2048,
+	}, snapshots[0])
+	require.Equal(t, "old", snapshots[1].Name)
+	require.Equal(t, "undated", snapshots[2].Name)
+	require.True(t, snapshots[2].CreatedAt.IsZero(), "expected a zero date for an unknown format")
+
+	remote, err := c.Backup.List("1", BackupKindRemote)
+	require.NoError(t, err)
+	require.Equal(t, "/backups/a.tar.gz", remote[0].Path)
+	require.Equal(t, "eu-central", remote[0].Location)
+	require.Equal(t, "https://backups.example.com/a.tar.gz", remote[0].DownloadURL)
+
+	_, err = c.Backup.List("1", "tape")
+	require.EqualError(t, err, "invalid backup kind 'tape'")
+}
+
+func TestBackupHandler_DeleteOlderThan(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/backups/getBackupsList":
+			_, _ = fmt.Fprint(w, `{"status":"OK","data":[
+				{"id":"a","date":"2024-01-01T00:00:00Z"},
+				{"id":"b","date":"2024-03-01T00:00:00Z"}
+			]}`)
+		case "/api/backups/DeleteBackup":
+			var req struct {
+				BackupID string `json:"backupID"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			deleted = append(deleted, req.BackupID)
+			mu.Unlock()
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	backups, err := c.Backup.DeleteOlderThan("1", BackupKindRemote, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	require.Equal(t, []string{"a"}, deleted)
+}
+
+func TestBackupHandler_Restore(t *testing.T) {
+	var req map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/servers/DoActionOnServer", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	err := c.Backup.Restore(Backup{ID: "2", ServiceID: "1", Kind: BackupKindSnapshot})
+	require.NoError(t, err)
+	require.Equal(t, "restoreSnapshot", req["action"])
+	require.Equal(t, "2", req["snapshotID"])
+	require.Equal(t, "1", req["vmID"])
+}
+
+func TestBackupHandler_RestoreDelete_MissingIDs(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	for _, kind := range []BackupKind{BackupKindSnapshot, BackupKindRemote} {
+		require.EqualError(t, c.Backup.Restore(Backup{ServiceID: "1", Kind: kind}), "backup id is required")
+		require.EqualError(t, c.Backup.Delete(Backup{ServiceID: "1", Kind: kind}), "backup id is required")
+		require.EqualError(t, c.Backup.Delete(Backup{ID: "2", Kind: kind}), "service id of backup 2 is required")
+	}
+	require.Zero(t, requests, "expected no request to be sent")
+}
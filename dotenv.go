@@ -0,0 +1,292 @@
+package elestio
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Dotenv is a parsed .env file. It keeps comments, blank lines, quoting and
+// the order of the variables so that it can be written back with String
+// without rewriting the lines that were not changed.
+type Dotenv struct {
+	entries []dotenvEntry
+}
+
+// dotenvEntry is a variable or, when key is empty, a line kept as is.
+type dotenvEntry struct {
+	raw    string // original text, may span several lines
+	export bool
+	key    string
+	value  string
+	quote  byte   // 0, '"', '\'' or '`'
+	suffix string // text after the value, e.g. " # comment"
+	dirty  bool
+}
+
+// DotenvSyntaxError reports a line of a .env file that cannot be parsed.
+type DotenvSyntaxError struct {
+	Line    int // 1-based
+	Message string
+}
+
+func (e *DotenvSyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ParseDotenv parses the content of a .env file.
+//
+// Values may be unquoted, single quoted, backquoted or double quoted, in
+// which case they can span several lines and \n, \", \\ are unescaped.
+// Lines that are neither a variable nor a comment are kept as is.
+//
+// The returned Dotenv is never nil: a line that cannot be parsed, such as
+// an unterminated quoted value, is kept as is and reported in the error as
+// a *DotenvSyntaxError, the following lines are still parsed.
+func ParseDotenv(s string) (*Dotenv, error) {
+	d := &Dotenv{}
+	var errs []error
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	// A trailing newline does not start a new line
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			d.entries = append(d.entries, dotenvEntry{raw: line})
+			continue
+		}
+
+		e := dotenvEntry{}
+		rest := trimmed
+		if after, ok := strings.CutPrefix(rest, "export "); ok {
+			e.export = true
+			rest = strings.TrimLeft(after, " \t")
+		}
+
+		key, value, ok := strings.Cut(rest, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			d.entries = append(d.entries, dotenvEntry{raw: line})
+			continue
+		}
+		e.key = key
+		value = strings.TrimLeft(value, " \t")
+
+		if value != "" && strings.ContainsRune("\"'`", rune(value[0])) {
+			e.quote = value[0]
+			start := i
+			raw := line
+			body := value[1:]
+			// Quoted values may continue on the next lines
+			end := closingQuote(body, e.quote)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				raw += "\n" + lines[i]
+				body += "\n" + lines[i]
+				end = closingQuote(body, e.quote)
+			}
+			if end < 0 {
+				errs = append(errs, &DotenvSyntaxError{Line: start + 1, Message: fmt.Sprintf("unterminated quoted value for %s", key)})
+				d.entries = append(d.entries, dotenvEntry{raw: line})
+				i = start
+				continue
+			}
+			e.raw = raw
+			e.value = body[:end]
+			if e.quote == '"' {
+				e.value = unescapeDotenv(e.value)
+			}
+			e.suffix = body[end+1:]
+		} else {
+			e.raw = line
+			e.value = value
+			if idx := strings.Index(value, " #"); idx >= 0 {
+				e.value, e.suffix = value[:idx], value[idx:]
+			}
+			e.value = strings.TrimRight(e.value, " \t")
+		}
+
+		d.entries = append(d.entries, e)
+	}
+
+	return d, errors.Join(errs...)
+}
+
+// closingQuote returns the index of the quote closing s, or -1.
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+func escapeDotenv(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// Get returns the value of key, the last one if it is set several times.
+func (d *Dotenv) Get(key string) (string, bool) {
+	for i := len(d.entries) - 1; i >= 0; i-- {
+		if d.entries[i].key == key {
+			return d.entries[i].value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set sets the value of key, keeping its position and quoting if it
+// exists, or appends it to the file. It returns an error if key cannot be
+// written as a variable name.
+func (d *Dotenv) Set(key, value string) error {
+	if err := validateDotenvKey(key); err != nil {
+		return err
+	}
+
+	found := false
+	for i := range d.entries {
+		e := &d.entries[i]
+		if e.key != key {
+			continue
+		}
+		found = true
+		if e.value != value {
+			e.value, e.dirty = value, true
+		}
+	}
+
+	if !found {
+		d.entries = append(d.entries, dotenvEntry{key: key, value: value, dirty: true})
+	}
+
+	return nil
+}
+
+// validateDotenvKey checks that key is read back as the same variable name.
+func validateDotenvKey(key string) error {
+	switch {
+	case key == "":
+		return errors.New("invalid variable name: empty")
+	case strings.ContainsRune(key, '='):
+		return fmt.Errorf("invalid variable name '%s': contains '='", key)
+	case strings.ContainsFunc(key, unicode.IsSpace):
+		return fmt.Errorf("invalid variable name '%s': contains whitespace", key)
+	case strings.HasPrefix(key, "#"):
+		return fmt.Errorf("invalid variable name '%s': starts with '#'", key)
+	}
+
+	return nil
+}
+
+// Unset removes every occurrence of key.
+func (d *Dotenv) Unset(key string) {
+	d.entries = slices.DeleteFunc(d.entries, func(e dotenvEntry) bool {
+		return e.key == key
+	})
+}
+
+// Keys returns the variable names in the order of the file.
+func (d *Dotenv) Keys() []string {
+	keys := []string{}
+	for _, e := range d.entries {
+		if e.key != "" && !slices.Contains(keys, e.key) {
+			keys = append(keys, e.key)
+		}
+	}
+
+	return keys
+}
+
+// Map returns the variables, the last value wins for duplicated keys.
+func (d *Dotenv) Map() map[string]string {
+	m := make(map[string]string)
+	for _, e := range d.entries {
+		if e.key != "" {
+			m[e.key] = e.value
+		}
+	}
+
+	return m
+}
+
+// String returns the content of the .env file, with a trailing newline.
+func (d *Dotenv) String() string {
+	var b strings.Builder
+	for _, e := range d.entries {
+		if e.dirty {
+			b.WriteString(e.format())
+		} else {
+			b.WriteString(e.raw)
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// format renders a changed variable, keeping its original style when the
+// new value allows it.
+func (e dotenvEntry) format() string {
+	var b strings.Builder
+	if e.export {
+		b.WriteString("export ")
+	}
+	b.WriteString(e.key)
+	b.WriteByte('=')
+
+	quote := e.quote
+	switch {
+	case quote == 0 && strings.ContainsAny(e.value, " \t#\"'`\\\n"):
+		quote = '"'
+	case quote != 0 && quote != '"' && strings.ContainsAny(e.value, string(quote)+"\n"):
+		quote = '"'
+	}
+
+	switch quote {
+	case 0:
+		b.WriteString(e.value)
+	case '"':
+		b.WriteString(`"` + escapeDotenv(e.value) + `"`)
+	default:
+		b.WriteByte(quote)
+		b.WriteString(e.value)
+		b.WriteByte(quote)
+	}
+	b.WriteString(e.suffix)
+
+	return b.String()
+}
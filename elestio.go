@@ -1,9 +1,14 @@
 package elestio
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -11,18 +16,39 @@ const (
 )
 
 type Client struct {
-	BaseURL    string
-	HTTPClient *http.Client
-	Email      string
-	ApiKey     string
-	jwt        string
+	BaseURL        string
+	HTTPClient     *http.Client
+	RetryPolicy    *RetryPolicy
+	UserAgent      string
+	Logger         *slog.Logger
+	AuthMode       AuthMode
+	Middlewares    []Middleware
+	TracerProvider trace.TracerProvider
+	Email          string
+	ApiKey         string
+	jwt            string
+	jwtMu          sync.RWMutex
+	signInMu       sync.Mutex
+	lazySignIn     bool
+	headerAuthOnly map[string]bool
+	strict         bool
+	limiter        *rateLimiter
+	inFlight       chan struct{}
 
 	Project      *ProjectHandler
 	Service      *ServiceHandler
 	LoadBalancer *LoadBalancerHandler
+	Backup       *BackupHandler
+}
+
+// NewClient creates a client and signs in with the given credentials,
+// use WithoutEagerSignIn to defer the sign in to the first request.
+func NewClient(email, apiKey string, opts ...Option) (*Client, error) {
+	return NewClientWithContext(context.Background(), email, apiKey, opts...)
 }
 
-func NewClient(email, apiKey string) (*Client, error) {
+// NewClientWithContext is like NewClient but uses ctx for the sign in request.
+func NewClientWithContext(ctx context.Context, email, apiKey string, opts ...Option) (*Client, error) {
 	if email == "" {
 		return nil, errors.New("email is required")
 	}
@@ -32,14 +58,21 @@ func NewClient(email, apiKey string) (*Client, error) {
 	}
 
 	client := Client{
-		BaseURL:    BaseURLV1,
-		HTTPClient: &http.Client{},
-		Email:      email,
-		ApiKey:     apiKey,
+		BaseURL:     BaseURLV1,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: DefaultRetryPolicy(),
+		Email:       email,
+		ApiKey:      apiKey,
+	}
+
+	for _, opt := range opts {
+		opt(&client)
 	}
 
-	if err := client.signIn(); err != nil {
-		return nil, fmt.Errorf("failed to sign in: %s", err)
+	if !client.lazySignIn {
+		if err := client.signIn(ctx); err != nil {
+			return nil, fmt.Errorf("failed to sign in: %w", err)
+		}
 	}
 
 	client.init()
@@ -47,10 +80,15 @@ func NewClient(email, apiKey string) (*Client, error) {
 	return &client, nil
 }
 
-func NewUnsignedClient() *Client {
+func NewUnsignedClient(opts ...Option) *Client {
 	client := Client{
-		BaseURL:    BaseURLV1,
-		HTTPClient: &http.Client{},
+		BaseURL:     BaseURLV1,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(&client)
 	}
 
 	client.init()
@@ -63,4 +101,24 @@ func (c *Client) init() {
 	c.Project = &ProjectHandler{client: c}
 	c.Service = &ServiceHandler{client: c}
 	c.LoadBalancer = &LoadBalancerHandler{client: c}
+	c.Backup = &BackupHandler{client: c}
+}
+
+// retryPolicy returns the client retry policy, or the default one if unset.
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.RetryPolicy == nil {
+		return DefaultRetryPolicy()
+	}
+
+	return c.RetryPolicy
+}
+
+// logger returns the client logger, or slog.Default() if unset, for
+// warnings only: requests are logged only with WithLogger.
+func (c *Client) logger() *slog.Logger {
+	if c.Logger == nil {
+		return slog.Default()
+	}
+
+	return c.Logger
 }
@@ -0,0 +1,118 @@
+package elestio
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized is returned when the API rejects the credentials or the jwt.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrRateLimited is returned when the API throttled the request.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// APIError is returned when Elestio answers with a non 2xx status code
+// or with a "KO" APIResponse.
+//
+// It matches ErrNotFound, ErrUnauthorized and ErrRateLimited with errors.Is
+// depending on the HTTP status code and the Elestio message.
+type APIError struct {
+	// HTTPStatusCode is the HTTP status code of the response.
+	HTTPStatusCode int
+	// Status is the Elestio status of the response, e.g. "KO".
+	Status string
+	// Message is the Elestio message of the response.
+	Message string
+	// Endpoint is the URL the request was sent to.
+	Endpoint string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("request failed with status code %d: %s", e.HTTPStatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("request failed with status code %d: %s", e.HTTPStatusCode, string(e.Body))
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPStatusCode == http.StatusNotFound || strings.Contains(strings.ToLower(e.Message), "not found")
+	case ErrUnauthorized:
+		return e.HTTPStatusCode == http.StatusUnauthorized || e.HTTPStatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.HTTPStatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
+
+// newAPIError builds an APIError from a response, the Elestio status and
+// message are extracted from the body when it is a valid APIResponse.
+func newAPIError(endpoint string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		HTTPStatusCode: statusCode,
+		Endpoint:       endpoint,
+		Body:           body,
+	}
+
+	var res APIResponse
+	if err := checkAPIResponse(body, &res); err == nil {
+		apiErr.Status = res.Status
+		apiErr.Message = res.Message
+	}
+
+	return apiErr
+}
+
+// PartialError describes the sub-resources of a service that could not
+// be fetched by ServiceHandler.Get or ServiceHandler.GetList.
+type PartialError struct {
+	ServiceID string
+	Failures  []EnrichmentFailure
+}
+
+// EnrichmentFailure is the error that prevented fetching a sub-resource.
+type EnrichmentFailure struct {
+	Enrichment ServiceEnrichment
+	Err        error
+}
+
+func (e *PartialError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", f.Enrichment, f.Err))
+	}
+
+	return fmt.Sprintf("service %s partially fetched, failed to get %s", e.ServiceID, strings.Join(msgs, "; "))
+}
+
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+
+	return errs
+}
+
+// Failed reports whether fetching any of the given sub-resources failed.
+func (e *PartialError) Failed(enrichment ServiceEnrichment) bool {
+	for _, f := range e.Failures {
+		if enrichment&f.Enrichment != 0 {
+			return true
+		}
+	}
+
+	return false
+}
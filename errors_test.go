@@ -0,0 +1,53 @@
+package elestio
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	require.ErrorIs(t, &APIError{HTTPStatusCode: http.StatusNotFound}, ErrNotFound)
+	require.ErrorIs(t, &APIError{HTTPStatusCode: http.StatusOK, Status: "KO", Message: "Server not found"}, ErrNotFound)
+	require.ErrorIs(t, &APIError{HTTPStatusCode: http.StatusUnauthorized}, ErrUnauthorized)
+	require.ErrorIs(t, &APIError{HTTPStatusCode: http.StatusTooManyRequests}, ErrRateLimited)
+	require.NotErrorIs(t, &APIError{HTTPStatusCode: http.StatusInternalServerError}, ErrNotFound)
+}
+
+func TestSendRequest_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"status":"KO","message":"invalid jwt"}`))
+	}))
+	defer srv.Close()
+
+	c := NewUnsignedClient()
+	c.BaseURL = srv.URL
+
+	_, err := c.Project.GetList()
+	require.ErrorIs(t, err, ErrUnauthorized, "expected unauthorized error")
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr), "expected an APIError")
+	require.Equal(t, http.StatusUnauthorized, apiErr.HTTPStatusCode)
+	require.Equal(t, "KO", apiErr.Status)
+	require.Equal(t, "invalid jwt", apiErr.Message)
+	require.Equal(t, srv.URL+"/api/projects/getList", apiErr.Endpoint)
+}
+
+func TestSendRequest_KOResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"KO","message":"Project not found"}`))
+	}))
+	defer srv.Close()
+
+	c := NewUnsignedClient()
+	c.BaseURL = srv.URL
+
+	err := c.Project.Delete("1")
+	require.ErrorIs(t, err, ErrNotFound, "expected not found error")
+	require.EqualError(t, err, "request failed with status code 200: Project not found")
+}
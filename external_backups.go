@@ -0,0 +1,183 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+)
+
+// ExternalBackupFrequency is how often external backups run.
+type ExternalBackupFrequency string
+
+const (
+	ExternalBackupFrequencyDaily  ExternalBackupFrequency = "daily"
+	ExternalBackupFrequencyWeekly ExternalBackupFrequency = "weekly"
+)
+
+// ExternalBackupConfig configures the backups of a service to an
+// S3-compatible storage.
+//
+// The secret key is never printed: ExternalBackupConfig implements
+// fmt.Stringer, fmt.GoStringer and slog.LogValuer, and the request field is
+// redacted from the client logs.
+type ExternalBackupConfig struct {
+	// Endpoint is the URL of the S3-compatible API, e.g. https://s3.amazonaws.com.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Frequency       ExternalBackupFrequency
+	// Schedule is when the backups run, Weekday is ignored for daily backups.
+	Schedule AutoUpdateSchedule
+	// RetentionDays is how many days backups are kept. The API reads it back
+	// in Service.ExternalBackupsRetainDayOfWeek, which is a number of days
+	// too despite its name.
+	RetentionDays int
+}
+
+// bucketNameRegexp matches the S3 bucket naming rules.
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// Validate checks the storage, the credentials, the schedule and the retention.
+func (c ExternalBackupConfig) Validate() error {
+	var errs []error
+	if u, err := url.Parse(c.Endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("invalid endpoint '%s', must be an http or https URL", c.Endpoint))
+	}
+	if !bucketNameRegexp.MatchString(c.Bucket) {
+		errs = append(errs, fmt.Errorf("invalid bucket name '%s'", c.Bucket))
+	}
+	if c.AccessKeyID == "" {
+		errs = append(errs, errors.New("access key id is required"))
+	}
+	if c.SecretAccessKey == "" {
+		errs = append(errs, errors.New("secret access key is required"))
+	}
+	if c.Frequency != ExternalBackupFrequencyDaily && c.Frequency != ExternalBackupFrequencyWeekly {
+		errs = append(errs, fmt.Errorf("invalid frequency '%s', must be '%s' or '%s'", c.Frequency, ExternalBackupFrequencyDaily, ExternalBackupFrequencyWeekly))
+	}
+	if err := c.Schedule.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.RetentionDays < 1 {
+		errs = append(errs, fmt.Errorf("invalid retention %d days, must be at least 1", c.RetentionDays))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns the config with the secret access key redacted.
+func (c ExternalBackupConfig) String() string {
+	c.SecretAccessKey = redactedValue
+	type plain ExternalBackupConfig
+	return fmt.Sprintf("%+v", plain(c))
+}
+
+// GoString returns the config with the secret access key redacted, it is
+// used by the %#v verb.
+func (c ExternalBackupConfig) GoString() string {
+	c.SecretAccessKey = redactedValue
+	type plain ExternalBackupConfig
+	return fmt.Sprintf("%#v", plain(c))
+}
+
+// LogValue returns the config with the secret access key redacted.
+func (c ExternalBackupConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("endpoint", c.Endpoint),
+		slog.String("region", c.Region),
+		slog.String("bucket", c.Bucket),
+		slog.String("accessKeyID", c.AccessKeyID),
+		slog.String("secretAccessKey", redactedValue),
+		slog.String("frequency", string(c.Frequency)),
+		slog.Int("retentionDays", c.RetentionDays),
+	)
+}
+
+func (h *ServiceHandler) EnableExternalBackups(serviceId string, config ExternalBackupConfig) error {
+	return h.EnableExternalBackupsCtx(context.Background(), serviceId, config)
+}
+
+// EnableExternalBackupsCtx is like EnableExternalBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableExternalBackupsCtx(ctx context.Context, serviceId string, config ExternalBackupConfig) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableExternalBackups")
+	defer span.end(&err)
+
+	return h.setExternalBackups(ctx, serviceId, "enableExternalBackup", config)
+}
+
+// UpdateExternalBackups replaces the external backup configuration of a
+// service whose external backups are enabled.
+func (h *ServiceHandler) UpdateExternalBackups(service *Service, config ExternalBackupConfig) error {
+	return h.UpdateExternalBackupsCtx(context.Background(), service, config)
+}
+
+// UpdateExternalBackupsCtx is like UpdateExternalBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateExternalBackupsCtx(ctx context.Context, service *Service, config ExternalBackupConfig) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateExternalBackups")
+	defer span.end(&err)
+
+	if service.ExternalBackupsEnabled == 0 {
+		return fmt.Errorf("external backups are disabled on service %s", service.ID)
+	}
+
+	return h.setExternalBackups(ctx, service.ID, "updateExternalBackup", config)
+}
+
+func (h *ServiceHandler) DisableExternalBackups(serviceId string) error {
+	return h.DisableExternalBackupsCtx(context.Background(), serviceId)
+}
+
+// DisableExternalBackupsCtx is like DisableExternalBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableExternalBackupsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableExternalBackups")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "disableExternalBackup")
+}
+
+func (h *ServiceHandler) setExternalBackups(ctx context.Context, serviceId, action string, config ExternalBackupConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid external backup config: %w", err)
+	}
+	schedule := config.Schedule.utc()
+
+	req := struct {
+		ServiceID       string `json:"vmID"`
+		Action          string `json:"action"`
+		Endpoint        string `json:"externalBackupEndpoint"`
+		Region          string `json:"externalBackupRegion,omitempty"`
+		Bucket          string `json:"externalBackupBucket"`
+		AccessKeyID     string `json:"externalBackupAccessKey"`
+		SecretAccessKey string `json:"externalBackupSecretKey"`
+		UpdateType      string `json:"externalBackupUpdateType"`
+		UpdateDay       int64  `json:"externalBackupUpdateDay"`
+		UpdateHour      int64  `json:"externalBackupUpdateHour"`
+		UpdateMinute    int64  `json:"externalBackupUpdateMinute"`
+		RetainDay       int64  `json:"externalBackupRetainDay"`
+	}{
+		ServiceID:       serviceId,
+		Action:          action,
+		Endpoint:        config.Endpoint,
+		Region:          config.Region,
+		Bucket:          config.Bucket,
+		AccessKeyID:     config.AccessKeyID,
+		SecretAccessKey: config.SecretAccessKey,
+		UpdateType:      string(config.Frequency),
+		UpdateDay:       int64(schedule.Weekday),
+		UpdateHour:      int64(schedule.Hour),
+		UpdateMinute:    int64(schedule.Minute),
+		RetainDay:       int64(config.RetentionDays),
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
@@ -0,0 +1,78 @@
+package elestio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testExternalBackupConfig() ExternalBackupConfig {
+	return ExternalBackupConfig{
+		Endpoint:        "https://s3.eu-west-1.amazonaws.com",
+		Region:          "eu-west-1",
+		Bucket:          "my-backups",
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "s3-secret-key",
+		Frequency:       ExternalBackupFrequencyWeekly,
+		Schedule:        AutoUpdateSchedule{Weekday: time.Saturday, Hour: 2, Minute: 30},
+		RetentionDays:   30,
+	}
+}
+
+func TestExternalBackupConfig_Validate(t *testing.T) {
+	require.NoError(t, testExternalBackupConfig().Validate())
+
+	err := ExternalBackupConfig{Endpoint: "s3.amazonaws.com", Bucket: "My_Bucket", Frequency: "hourly", Schedule: AutoUpdateSchedule{Hour: 25}}.Validate()
+	require.EqualError(t, err, "invalid endpoint 's3.amazonaws.com', must be an http or https URL\n"+
+		"invalid bucket name 'My_Bucket'\n"+
+		"access key id is required\n"+
+		"secret access key is required\n"+
+		"invalid frequency 'hourly', must be 'daily' or 'weekly'\n"+
+		"invalid hour 25, must be between 0 and 23\n"+
+		"invalid retention 0 days, must be at least 1")
+}
+
+func TestExternalBackupConfig_Redacted(t *testing.T) {
+	config := testExternalBackupConfig()
+	require.NotContains(t, fmt.Sprint(config), "s3-secret-key")
+	require.NotContains(t, fmt.Sprintf("%+v", config), "s3-secret-key")
+	require.NotContains(t, fmt.Sprintf("%#v", config), "s3-secret-key")
+	require.Contains(t, fmt.Sprintf("%#v", config), "my-backups")
+
+	var logs bytes.Buffer
+	slog.New(slog.NewTextHandler(&logs, nil)).Info("config", "config", config)
+	require.NotContains(t, logs.String(), "s3-secret-key")
+	require.Contains(t, logs.String(), "config.bucket=my-backups")
+}
+
+func TestServiceHandler_EnableExternalBackups(t *testing.T) {
+	var req map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+	}))
+	defer srv.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithLogger(logger))
+
+	require.NoError(t, c.Service.EnableExternalBackups("1", testExternalBackupConfig()))
+	require.Equal(t, "enableExternalBackup", req["action"])
+	require.Equal(t, "s3-secret-key", req["externalBackupSecretKey"])
+	require.Equal(t, "weekly", req["externalBackupUpdateType"])
+	require.Equal(t, float64(6), req["externalBackupUpdateDay"])
+	require.Equal(t, float64(30), req["externalBackupRetainDay"])
+	require.NotContains(t, logs.String(), "s3-secret-key")
+
+	err := c.Service.UpdateExternalBackups(&Service{ID: "1"}, testExternalBackupConfig())
+	require.EqualError(t, err, "external backups are disabled on service 1")
+}
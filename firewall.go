@@ -0,0 +1,306 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// firewallAnywhere is the target matching every address.
+const firewallAnywhere = "anywhere"
+
+// Validate checks the type, the protocol, the port and the targets of the
+// rule. Ports are a number from 1 to 65535 or a range such as "8000-8100",
+// and are ignored for icmp. Targets are IPv4 or IPv6 addresses or CIDRs,
+// or "anywhere".
+func (r ServiceFirewallRule) Validate() error {
+	var errs []error
+	if r.Type != ServiceFirewallRuleTypeInput && r.Type != ServiceFirewallRuleTypeOutput {
+		errs = append(errs, fmt.Errorf("invalid rule type '%s': only '%s' and '%s' are supported", r.Type, ServiceFirewallRuleTypeInput, ServiceFirewallRuleTypeOutput))
+	}
+
+	switch r.Protocol {
+	case ServiceFirewallRuleProtocolTCP, ServiceFirewallRuleProtocolUDP:
+		if err := validateFirewallPort(r.Port); err != nil {
+			errs = append(errs, err)
+		}
+	case ServiceFirewallRuleProtocolICMP:
+	default:
+		errs = append(errs, fmt.Errorf("invalid protocol '%s': only '%s', '%s' and '%s' are supported", r.Protocol, ServiceFirewallRuleProtocolTCP, ServiceFirewallRuleProtocolUDP, ServiceFirewallRuleProtocolICMP))
+	}
+
+	if len(r.Targets) == 0 {
+		errs = append(errs, errors.New("at least one target is required"))
+	}
+	for _, target := range r.Targets {
+		if err := validateFirewallTarget(target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateFirewallRules validates every rule and returns the errors of
+// all the invalid ones, prefixed by their index.
+func ValidateFirewallRules(rules []ServiceFirewallRule) error {
+	var errs []error
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateFirewallPort(port string) error {
+	from, to, isRange := strings.Cut(port, "-")
+
+	fromPort, err := parseFirewallPort(from)
+	if err != nil {
+		return fmt.Errorf("invalid port '%s': %w", port, err)
+	}
+	if !isRange {
+		return nil
+	}
+
+	toPort, err := parseFirewallPort(to)
+	if err != nil {
+		return fmt.Errorf("invalid port range '%s': %w", port, err)
+	}
+	if fromPort > toPort {
+		return fmt.Errorf("invalid port range '%s': start is greater than end", port)
+	}
+
+	return nil
+}
+
+func parseFirewallPort(port string) (int, error) {
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return 0, errors.New("must be between 1 and 65535")
+	}
+
+	return p, nil
+}
+
+func validateFirewallTarget(target string) error {
+	if target == firewallAnywhere {
+		return nil
+	}
+	if _, err := netip.ParsePrefix(target); err == nil {
+		return nil
+	}
+	if _, err := netip.ParseAddr(target); err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("invalid target '%s': must be an IPv4 or IPv6 CIDR or '%s'", target, firewallAnywhere)
+}
+
+// FirewallDiff is the difference between the firewall rules of a service
+// and the desired ones, computed on normalised rules.
+type FirewallDiff struct {
+	Added   []ServiceFirewallRule
+	Removed []ServiceFirewallRule
+}
+
+// IsEmpty reports whether the rules are already the desired ones.
+func (d *FirewallDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// ReconcileOption configures ServiceHandler.ReconcileFirewall.
+type ReconcileOption func(*reconcileOptions)
+
+type reconcileOptions struct {
+	dryRun bool
+}
+
+// WithDryRun only computes the diff, the rules are left untouched.
+func WithDryRun() ReconcileOption {
+	return func(o *reconcileOptions) {
+		o.dryRun = true
+	}
+}
+
+// ReconcileFirewall makes desired the firewall rules of a deployed service
+// and returns what changed. The rules are only written when the diff is
+// not empty, the firewall is enabled if needed.
+//
+// Rules are compared once normalised: type and protocol case, port ranges
+// such as "8000 - 8100" or "8000:8100", targets order and CIDR notation do
+// not matter.
+func (h *ServiceHandler) ReconcileFirewall(projectID, serviceID string, desired []ServiceFirewallRule, opts ...ReconcileOption) (*FirewallDiff, error) {
+	return h.ReconcileFirewallCtx(context.Background(), projectID, serviceID, desired, opts...)
+}
+
+// ReconcileFirewallCtx is like ReconcileFirewall but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) ReconcileFirewallCtx(ctx context.Context, projectID, serviceID string, desired []ServiceFirewallRule, opts ...ReconcileOption) (_ *FirewallDiff, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.ReconcileFirewall")
+	defer span.end(&err)
+
+	var o reconcileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	validated := make([]ServiceFirewallRule, 0, len(desired))
+	for _, rule := range desired {
+		validated = append(validated, rule.normalise())
+	}
+	if err = ValidateFirewallRules(validated); err != nil {
+		return nil, err
+	}
+
+	service, err := h.GetCtx(ctx, projectID, serviceID, WithoutEnrichment())
+	if err != nil {
+		return nil, err
+	}
+
+	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
+		return nil, fmt.Errorf("service %s is not deployed", serviceID)
+	}
+
+	// An empty rule set would remove every rule, never fall back to it
+	current, err := h.GetServiceFirewallRulesCtx(StrictContext(ctx), service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall rules: %w", err)
+	}
+
+	normalised := dedupeFirewallRules(validated)
+	diff := diffFirewallRules(normaliseFirewallRules(*current), normalised)
+
+	if o.dryRun || diff.IsEmpty() {
+		return diff, nil
+	}
+
+	if service.FirewallEnabled == 0 {
+		err = h.EnableFirewallWithRulesCtx(ctx, serviceID, normalised)
+	} else {
+		err = h.UpdateFirewallRulesCtx(ctx, serviceID, normalised)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// diffFirewallRules returns the rules of desired missing from current and
+// the rules of current missing from desired.
+func diffFirewallRules(current, desired []ServiceFirewallRule) *FirewallDiff {
+	diff := &FirewallDiff{Added: []ServiceFirewallRule{}, Removed: []ServiceFirewallRule{}}
+
+	currentKeys := make(map[string]bool, len(current))
+	for _, rule := range current {
+		currentKeys[rule.key()] = true
+	}
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		desiredKeys[rule.key()] = true
+	}
+
+	for _, rule := range desired {
+		if !currentKeys[rule.key()] {
+			diff.Added = append(diff.Added, rule)
+		}
+	}
+	for _, rule := range current {
+		if !desiredKeys[rule.key()] {
+			diff.Removed = append(diff.Removed, rule)
+		}
+	}
+
+	return diff
+}
+
+// normaliseFirewallRules returns the normalised rules without duplicates.
+func normaliseFirewallRules(rules []ServiceFirewallRule) []ServiceFirewallRule {
+	normalised := make([]ServiceFirewallRule, 0, len(rules))
+	for _, rule := range rules {
+		normalised = append(normalised, rule.normalise())
+	}
+
+	return dedupeFirewallRules(normalised)
+}
+
+// dedupeFirewallRules returns the already normalised rules without duplicates.
+func dedupeFirewallRules(rules []ServiceFirewallRule) []ServiceFirewallRule {
+	deduped := make([]ServiceFirewallRule, 0, len(rules))
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if key := rule.key(); !seen[key] {
+			seen[key] = true
+			deduped = append(deduped, rule)
+		}
+	}
+
+	return deduped
+}
+
+// normalise returns the rule with an upper case type, a lower case
+// protocol, a "from-to" port range and sorted canonical targets.
+func (r ServiceFirewallRule) normalise() ServiceFirewallRule {
+	r.Type = strings.ToUpper(strings.TrimSpace(r.Type))
+	r.Protocol = strings.ToLower(strings.TrimSpace(r.Protocol))
+	r.Port = normalisePort(r.Port)
+
+	targets := make([]string, 0, len(r.Targets))
+	for _, target := range r.Targets {
+		targets = append(targets, normaliseTarget(target))
+	}
+	slices.Sort(targets)
+	r.Targets = slices.Compact(targets)
+
+	return r
+}
+
+// key identifies a normalised rule.
+func (r ServiceFirewallRule) key() string {
+	return strings.Join([]string{r.Type, r.Protocol, r.Port, strings.Join(r.Targets, ",")}, "|")
+}
+
+// normalisePort turns "08000 : 8100" into "8000-8100" and "80-80" into "80",
+// ports it cannot parse are only trimmed.
+func normalisePort(port string) string {
+	port = strings.TrimSpace(port)
+	from, to, isRange := strings.Cut(strings.ReplaceAll(port, ":", "-"), "-")
+
+	fromPort, err := strconv.Atoi(strings.TrimSpace(from))
+	if err != nil {
+		return port
+	}
+	if !isRange {
+		return strconv.Itoa(fromPort)
+	}
+
+	toPort, err := strconv.Atoi(strings.TrimSpace(to))
+	if err != nil {
+		return port
+	}
+	if fromPort == toPort {
+		return strconv.Itoa(fromPort)
+	}
+
+	return fmt.Sprintf("%d-%d", fromPort, toPort)
+}
+
+// normaliseTarget returns the canonical form of an IP address or a CIDR,
+// other targets such as "anywhere" are lower cased.
+func normaliseTarget(target string) string {
+	target = strings.TrimSpace(target)
+	if prefix, err := netip.ParsePrefix(target); err == nil {
+		return prefix.Masked().String()
+	}
+	if addr, err := netip.ParseAddr(target); err == nil {
+		return addr.String()
+	}
+
+	return strings.ToLower(target)
+}
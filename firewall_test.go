@@ -0,0 +1,152 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceFirewallRule_normalise(t *testing.T) {
+	rule := ServiceFirewallRule{
+		Type:     "input",
+		Port:     " 08000 : 8100 ",
+		Protocol: "TCP",
+		Targets:  []string{"10.0.0.1/8", "::FFFF", "Anywhere", "10.0.0.0/8"},
+	}
+
+	require.Equal(t, ServiceFirewallRule{
+		Type:     ServiceFirewallRuleTypeInput,
+		Port:     "8000-8100",
+		Protocol: ServiceFirewallRuleProtocolTCP,
+		Targets:  []string{"10.0.0.0/8", "::ffff", "anywhere"},
+	}, rule.normalise())
+
+	require.Equal(t, "443", normalisePort("443-443"))
+	require.Equal(t, "http", normalisePort("http"))
+}
+
+func TestDiffFirewallRules(t *testing.T) {
+	ssh := ServiceFirewallRule{Type: "INPUT", Port: "22", Protocol: "tcp", Targets: []string{"0.0.0.0/0", "::/0"}}
+	web := ServiceFirewallRule{Type: "INPUT", Port: "80", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}}
+	https := ServiceFirewallRule{Type: "INPUT", Port: "443", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}}
+	sshReordered := ServiceFirewallRule{Type: "input", Port: "22", Protocol: "TCP", Targets: []string{"::/0", "0.0.0.0/0"}}
+
+	diff := diffFirewallRules(
+		normaliseFirewallRules([]ServiceFirewallRule{ssh, web}),
+		normaliseFirewallRules([]ServiceFirewallRule{sshReordered, https, https}),
+	)
+	require.Equal(t, []ServiceFirewallRule{https}, diff.Added)
+	require.Equal(t, []ServiceFirewallRule{web}, diff.Removed)
+	require.False(t, diff.IsEmpty())
+}
+
+// setupFirewallReconcileServer serves a deployed service with the firewall
+// enabled and the given rules, and records the written rules.
+func setupFirewallReconcileServer(t *testing.T, rules string) (*Client, *[]ServiceFirewallRule) {
+	var written []ServiceFirewallRule
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/servers/getServerDetails" {
+			_, _ = fmt.Fprint(w, `{"status":"OK","serviceInfos":[{"vmID":"1","deploymentStatus":"Deployed","isFirewallActivated":1}]}`)
+			return
+		}
+
+		var req struct {
+			Action string                `json:"action"`
+			Rules  []ServiceFirewallRule `json:"rules"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Action {
+		case "getFirewallRules":
+			_, _ = fmt.Fprintf(w, `{"status":"OK","rules":%s}`, rules)
+		case "updateFirewall":
+			written = req.Rules
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewUnsignedClient(WithBaseURL(srv.URL)), &written
+}
+
+func TestServiceHandler_ReconcileFirewall(t *testing.T) {
+	c, written := setupFirewallReconcileServer(t, `[{"type":"INPUT","port":"22","protocol":"tcp","targets":["0.0.0.0/0"]}]`)
+	desired := []ServiceFirewallRule{
+		{Type: "INPUT", Port: "22", Protocol: "TCP", Targets: []string{"0.0.0.0/0"}},
+		{Type: "INPUT", Port: "443", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}},
+	}
+
+	diff, err := c.Service.ReconcileFirewall("1", "1", desired, WithDryRun())
+	require.NoError(t, err)
+	require.Len(t, diff.Added, 1)
+	require.Empty(t, diff.Removed)
+	require.Nil(t, *written)
+
+	diff, err = c.Service.ReconcileFirewall("1", "1", desired)
+	require.NoError(t, err)
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, normaliseFirewallRules(desired), *written)
+}
+
+func TestServiceHandler_ReconcileFirewall_NoChange(t *testing.T) {
+	c, written := setupFirewallReconcileServer(t, `[{"type":"INPUT","port":"8000-8100","protocol":"tcp","targets":["::/0","0.0.0.0/0"]}]`)
+
+	diff, err := c.Service.ReconcileFirewall("1", "1", []ServiceFirewallRule{
+		{Type: "INPUT", Port: "8000:8100", Protocol: "tcp", Targets: []string{"0.0.0.0/0", "::/0"}},
+	})
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+	require.Nil(t, *written)
+}
+
+func TestServiceFirewallRule_Validate(t *testing.T) {
+	valid := []ServiceFirewallRule{
+		{Type: "INPUT", Port: "22", Protocol: "tcp", Targets: []string{"0.0.0.0/0", "::/0"}},
+		{Type: "OUTPUT", Port: "8000-8100", Protocol: "udp", Targets: []string{"10.0.0.1", "2001:db8::/32"}},
+		{Type: "INPUT", Protocol: "icmp", Targets: []string{"anywhere"}},
+	}
+	require.NoError(t, ValidateFirewallRules(valid))
+
+	err := ServiceFirewallRule{Type: "FORWARD", Port: "70000", Protocol: "tcp", Targets: []string{"10.0.0.0/33"}}.Validate()
+	require.EqualError(t, err, "invalid rule type 'FORWARD': only 'INPUT' and 'OUTPUT' are supported\n"+
+		"invalid port '70000': must be between 1 and 65535\n"+
+		"invalid target '10.0.0.0/33': must be an IPv4 or IPv6 CIDR or 'anywhere'")
+
+	err = ServiceFirewallRule{Type: "INPUT", Port: "8100-8000", Protocol: "sctp"}.Validate()
+	require.EqualError(t, err, "invalid protocol 'sctp': only 'tcp', 'udp' and 'icmp' are supported\n"+
+		"at least one target is required")
+
+	require.EqualError(t, validateFirewallPort("8100-8000"), "invalid port range '8100-8000': start is greater than end")
+	require.EqualError(t, validateFirewallPort("80-"), "invalid port range '80-': must be between 1 and 65535")
+}
+
+func TestValidateFirewallRules(t *testing.T) {
+	err := ValidateFirewallRules([]ServiceFirewallRule{
+		{Type: "INPUT", Port: "80", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}},
+		{Type: "INVALID", Port: "443", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}},
+		{Type: "INPUT", Port: "abc", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}},
+	})
+	require.EqualError(t, err, "rule 1: invalid rule type 'INVALID': only 'INPUT' and 'OUTPUT' are supported\n"+
+		"rule 2: invalid port 'abc': must be between 1 and 65535")
+}
+
+func TestServiceHandler_EnableFirewallWithRules_Invalid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid rules must not reach the API")
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	err := c.Service.EnableFirewallWithRules("1", []ServiceFirewallRule{
+		{Type: "INVALID", Port: "443", Protocol: "tcp", Targets: []string{"0.0.0.0/0"}},
+	})
+	require.ErrorContains(t, err, "invalid rule type 'INVALID'")
+
+	_, err = c.Service.ReconcileFirewall("1", "1", []ServiceFirewallRule{
+		{Type: "input", Port: "70000", Protocol: "TCP", Targets: []string{"0.0.0.0/0"}},
+	}, WithDryRun())
+	require.EqualError(t, err, "rule 0: invalid port '70000': must be between 1 and 65535")
+}
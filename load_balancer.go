@@ -1,6 +1,7 @@
 package elestio
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -66,17 +67,24 @@ type (
 )
 
 func (h *LoadBalancerHandler) Get(projectID, loadBalancerID string) (*LoadBalancer, error) {
+	return h.GetCtx(context.Background(), projectID, loadBalancerID)
+}
+
+// GetCtx is like Get but uses ctx for the underlying HTTP requests.
+func (h *LoadBalancerHandler) GetCtx(ctx context.Context, projectID, loadBalancerID string) (_ *LoadBalancer, err error) {
+	ctx, span := h.client.startOperation(ctx, "LoadBalancer.Get")
+	defer span.end(&err)
+
 	// Fetch load balancer details
 	reqDetails := struct {
 		ProjectID      string `json:"projectID"`
 		LoadBalancerID string `json:"vmID"`
-		JWT            string `json:"jwt"`
 	}{
 		ProjectID:      projectID,
 		LoadBalancerID: loadBalancerID,
-		JWT:            h.client.jwt,
 	}
 	btsDetails, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/servers/getServerDetails", h.client.BaseURL),
 		reqDetails,
 	)
@@ -109,7 +117,7 @@ func (h *LoadBalancerHandler) Get(projectID, loadBalancerID string) (*LoadBalanc
 	}
 	// API returns an array of services, but we only need the first one
 	if len(resDetails.Services) == 0 {
-		return nil, fmt.Errorf("load balancer not found")
+		return nil, fmt.Errorf("load balancer %w", ErrNotFound)
 	}
 	details := resDetails.Services[0]
 
@@ -119,15 +127,14 @@ func (h *LoadBalancerHandler) Get(projectID, loadBalancerID string) (*LoadBalanc
 		LoadBalancerID  string `json:"loadBalancerID"`
 		IsRestoreLb     bool   `json:"isRestoreLb"`
 		IsActiveService bool   `json:"isActiveService"`
-		JWT             string `json:"jwt"`
 	}{
 		ProjectID:       projectID,
 		LoadBalancerID:  loadBalancerID,
 		IsRestoreLb:     false,
 		IsActiveService: true,
-		JWT:             h.client.jwt,
 	}
 	btsConfig, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/loadBalancer/getLBDetails", h.client.BaseURL),
 		reqConfig,
 	)
@@ -227,6 +234,14 @@ type CreateLoadBalancerRequestConfig struct {
 }
 
 func (h *LoadBalancerHandler) Create(req CreateLoadBalancerRequest) (*LoadBalancer, error) {
+	return h.CreateCtx(context.Background(), req)
+}
+
+// CreateCtx is like Create but uses ctx for the underlying HTTP requests.
+func (h *LoadBalancerHandler) CreateCtx(ctx context.Context, req CreateLoadBalancerRequest) (_ *LoadBalancer, err error) {
+	ctx, span := h.client.startOperation(ctx, "LoadBalancer.Create")
+	defer span.end(&err)
+
 	if req.CreatedFrom == "" {
 		req.CreatedFrom = "goClient"
 	}
@@ -235,15 +250,14 @@ func (h *LoadBalancerHandler) Create(req CreateLoadBalancerRequest) (*LoadBalanc
 		CreateLoadBalancerRequest
 		ServiceType string `json:"serviceType"`
 		TemplateID  string `json:"templateID"`
-		JWT         string `json:"jwt"`
 	}{
 		CreateLoadBalancerRequest: req,
 		ServiceType:               "LB",
-		JWT:                       h.client.jwt,
 		TemplateID:                "218",
 	}
 
 	bts, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/servers/createServer", h.client.BaseURL),
 		fullReq,
 	)
@@ -259,7 +273,7 @@ func (h *LoadBalancerHandler) Create(req CreateLoadBalancerRequest) (*LoadBalanc
 		return nil, err
 	}
 
-	return h.Get(req.ProjectID, (string)(res.ID[0]))
+	return h.GetCtx(ctx, req.ProjectID, (string)(res.ID[0]))
 }
 
 type UpdateLoadBalancerConfigRequest struct {
@@ -279,19 +293,25 @@ type UpdateLoadBalancerConfigRequest struct {
 }
 
 func (h *LoadBalancerHandler) UpdateConfig(projectID string, loadBalancerID string, req UpdateLoadBalancerConfigRequest) (*LoadBalancer, error) {
+	return h.UpdateConfigCtx(context.Background(), projectID, loadBalancerID, req)
+}
+
+// UpdateConfigCtx is like UpdateConfig but uses ctx for the underlying HTTP requests.
+func (h *LoadBalancerHandler) UpdateConfigCtx(ctx context.Context, projectID string, loadBalancerID string, req UpdateLoadBalancerConfigRequest) (_ *LoadBalancer, err error) {
+	ctx, span := h.client.startOperation(ctx, "LoadBalancer.UpdateConfig")
+	defer span.end(&err)
+
 	fullReq := struct {
 		UpdateLoadBalancerConfigRequest
 		LoadBalancerID string `json:"vmID"`
 		Action         string `json:"action"`
-		JWT            string `json:"jwt"`
 	}{
 		UpdateLoadBalancerConfigRequest: req,
 		LoadBalancerID:                  loadBalancerID,
 		Action:                          "updateLBSetting",
-		JWT:                             h.client.jwt,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), fullReq)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), fullReq)
 	if err != nil {
 		return nil, err
 	}
@@ -303,15 +323,22 @@ func (h *LoadBalancerHandler) UpdateConfig(projectID string, loadBalancerID stri
 		return nil, err
 	}
 
-	return h.Get(projectID, loadBalancerID)
+	return h.GetCtx(ctx, projectID, loadBalancerID)
 }
 
 func (h *LoadBalancerHandler) Delete(projectID, loadBalancerID string, keepBackups bool) error {
+	return h.DeleteCtx(context.Background(), projectID, loadBalancerID, keepBackups)
+}
+
+// DeleteCtx is like Delete but uses ctx for the underlying HTTP requests.
+func (h *LoadBalancerHandler) DeleteCtx(ctx context.Context, projectID, loadBalancerID string, keepBackups bool) (err error) {
+	ctx, span := h.client.startOperation(ctx, "LoadBalancer.Delete")
+	defer span.end(&err)
+
 	type deleteLoadBalancerRequest struct {
 		ProjectID       string `json:"projectID"`
 		LoadBalancerID  string `json:"vmID"`
 		IsWithoutBackup bool   `json:"isDeleteServiceWithBackup"`
-		JWT             string `json:"jwt"`
 	}
 
 	type deleteLoadBalancerResponse struct {
@@ -322,10 +349,9 @@ func (h *LoadBalancerHandler) Delete(projectID, loadBalancerID string, keepBacku
 		ProjectID:       projectID,
 		LoadBalancerID:  loadBalancerID,
 		IsWithoutBackup: !keepBackups,
-		JWT:             h.client.jwt,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/deleteServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/deleteServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,102 @@
+package elestio
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const redactedValue = "[REDACTED]"
+
+// redactedFields lists the JSON fields, lower cased, whose values
+// never reach the logs: the jwt, the API key sent as "token" on sign in,
+// the app password, the admin and database admin passwords and the
+// .env and docker-compose.yml files, which usually hold secrets, and the
+// secret key of the external backups storage.
+var redactedFields = map[string]bool{
+	"jwt":                     true,
+	"token":                   true,
+	"apikey":                  true,
+	"apppassword":             true,
+	"password":                true,
+	"envresult":               true,
+	"envdata":                 true,
+	"composeresult":           true,
+	"composedata":             true,
+	"externalbackupsecretkey": true,
+}
+
+// logExchange logs a request and its response, or the error that
+// prevented getting a response, at debug level. Nothing is logged unless
+// a logger was set with WithLogger, slog.Default() is never used here.
+func (c *Client) logExchange(ctx context.Context, op Operation, method, endpoint string, attempt int, reqBody []byte, statusCode int, rspBody []byte, err error, elapsed time.Duration) {
+	logger := c.Logger
+	if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", op.Name),
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", elapsed),
+		slog.String("request_body", redactBody(reqBody)),
+	}
+	if op.Action != "" {
+		attrs = append(attrs, slog.String("action", op.Action))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelDebug, "elestio request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs,
+		slog.Int("status_code", statusCode),
+		slog.String("response_body", redactBody(rspBody)),
+	)
+	logger.LogAttrs(ctx, slog.LevelDebug, "elestio request", attrs...)
+}
+
+// redactBody returns a JSON body with the values of redactedFields
+// replaced, at any depth. Bodies that are not JSON are returned as is.
+func redactBody(bts []byte) string {
+	if len(bts) == 0 {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(bts, &v); err != nil {
+		return string(bts)
+	}
+
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(bts)
+	}
+
+	return string(redacted)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if redactedFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+
+	return v
+}
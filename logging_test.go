@@ -0,0 +1,60 @@
+package elestio
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := `{"jwt":"secret-jwt","token":"secret-key","appPassword":"secret-app","data":[{"user":"root","password":"secret-admin"}],"vmID":"1"}`
+
+	redacted := redactBody([]byte(body))
+	require.NotContains(t, redacted, "secret")
+	require.Contains(t, redacted, `"vmID":"1"`)
+	require.Contains(t, redacted, `"user":"root"`)
+
+	require.Equal(t, "not json", redactBody([]byte("not json")))
+}
+
+func TestLogExchange_Redacted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK","user":"root","password":"admin-password"}`))
+	}))
+	defer srv.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithLogger(logger))
+	c.setToken("session-jwt")
+
+	_, err := c.Service.GetServiceAdmin(&Service{ID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed})
+	require.NoError(t, err)
+
+	output := logs.String()
+	require.Contains(t, output, "operation=Service.GetServiceAdmin")
+	require.Contains(t, output, "endpoint=/api/servers/getAppCredentials")
+	require.NotContains(t, output, "session-jwt")
+	require.NotContains(t, output, "admin-password")
+}
+
+func TestLogExchange_NoLoggerIgnoresDefault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(defaultLogger)
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+	require.NoError(t, c.Service.RebootServer("1"))
+	require.Empty(t, logs.String(), "expected no request logs without WithLogger")
+}
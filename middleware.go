@@ -0,0 +1,63 @@
+package elestio
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Operation describes the logical client operation a request belongs to.
+type Operation struct {
+	// Name is the handler method that started the operation, e.g. "Service.Create".
+	Name string
+
+	// Action is the DoActionOnServer action of the request, e.g. "SSLDomainsAdd".
+	Action string
+}
+
+// RequestFunc sends the HTTP request of an operation.
+type RequestFunc func(op Operation, req *http.Request) (*http.Response, error)
+
+// Middleware wraps the RequestFunc that sends every request of a Client.
+//
+// A middleware can inspect or mutate the request before calling next,
+// inspect or mutate the response it returns, or return a response or an
+// error without calling next at all.
+type Middleware func(next RequestFunc) RequestFunc
+
+type operationContextKey struct{}
+
+// authOperationPrefix is the prefix of the operations of the auth requests.
+const authOperationPrefix = "Auth."
+
+// withOperation returns a copy of ctx carrying the operation name, unless
+// ctx already carries one: nested handler calls belong to the outer operation.
+// Auth operations are the exception, a sign in triggered by another
+// operation is still reported as such.
+func withOperation(ctx context.Context, name string) context.Context {
+	if _, ok := ctx.Value(operationContextKey{}).(string); ok && !strings.HasPrefix(name, authOperationPrefix) {
+		return ctx
+	}
+
+	return context.WithValue(ctx, operationContextKey{}, name)
+}
+
+// OperationName returns the name of the operation carried by ctx, if any.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationContextKey{}).(string)
+	return name
+}
+
+// do sends req through the client middlewares, the first middleware
+// being the outermost one.
+func (c *Client) do(op Operation, req *http.Request) (*http.Response, error) {
+	next := func(_ Operation, req *http.Request) (*http.Response, error) {
+		return c.HTTPClient.Do(req)
+	}
+
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		next = c.Middlewares[i](next)
+	}
+
+	return next(op, req)
+}
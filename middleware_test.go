@@ -0,0 +1,83 @@
+package elestio
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_ReceivesOperation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "audit", r.Header.Get("X-Audit"), "expected header set by middleware")
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+
+	var ops []Operation
+	var order []string
+	record := func(next RequestFunc) RequestFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			order = append(order, "outer")
+			ops = append(ops, op)
+			return next(op, req)
+		}
+	}
+	inject := func(next RequestFunc) RequestFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			order = append(order, "inner")
+			req.Header.Set("X-Audit", "audit")
+			return next(op, req)
+		}
+	}
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithMiddleware(record, inject))
+
+	require.NoError(t, c.Service.AddCustomDomainName("1", "example.com"))
+	require.Equal(t, []Operation{{Name: "Service.AddCustomDomainName", Action: "SSLDomainsAdd"}}, ops)
+	require.Equal(t, []string{"outer", "inner"}, order)
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	c := NewUnsignedClient(WithBaseURL("http://127.0.0.1:0"), WithMiddleware(func(next RequestFunc) RequestFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"status":"OK","data":{"projects":[{"project_name":"stub"}]}}`)),
+			}, nil
+		}
+	}))
+
+	projects, err := c.Project.GetList()
+	require.NoError(t, err, "expected stubbed response")
+	require.Equal(t, "stub", (*projects)[0].Name)
+}
+
+func TestMiddleware_SignInOperation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == signInEndpoint {
+			_, _ = w.Write([]byte(`{"status":"OK","jwt":"secret"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+
+	var names []string
+	record := func(next RequestFunc) RequestFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			names = append(names, op.Name)
+			return next(op, req)
+		}
+	}
+
+	c, err := NewClient("user@example.com", "key", WithBaseURL(srv.URL), WithoutEagerSignIn(), WithMiddleware(record))
+	require.NoError(t, err)
+
+	// The lazy sign in happens inside Service.RebootServer
+	require.NoError(t, c.Service.RebootServer("1"))
+	require.Equal(t, []string{"Auth.SignIn", "Service.RebootServer"}, names)
+}
@@ -0,0 +1,123 @@
+package elestio
+
+import (
+	"log/slog"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a Client created by NewClient or NewUnsignedClient.
+type Option func(*Client)
+
+// WithBaseURL sets the Elestio API base URL, BaseURLV1 by default.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithLogger sets the logger the client logs its requests and responses to
+// at debug level. Without it, requests are not logged and warnings go to
+// slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithAuthMode sets where the jwt is sent, AuthModeLegacy by default.
+func WithAuthMode(mode AuthMode) Option {
+	return func(c *Client) {
+		c.AuthMode = mode
+	}
+}
+
+// WithHeaderAuthEndpoints sends the jwt only in the Authorization header to
+// the given endpoints, e.g. "/api/servers/DoActionOnServer", even with
+// AuthModeLegacy. Use it for the endpoints that stopped reading the jwt
+// from the query string and the body.
+func WithHeaderAuthEndpoints(endpoints ...string) Option {
+	return func(c *Client) {
+		if c.headerAuthOnly == nil {
+			c.headerAuthOnly = make(map[string]bool, len(endpoints))
+		}
+		for _, endpoint := range endpoints {
+			c.headerAuthOnly[endpoint] = true
+		}
+	}
+}
+
+// WithMiddleware appends middlewares to the chain that sends every request.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.Middlewares = append(c.Middlewares, middlewares...)
+	}
+}
+
+// WithTracerProvider enables OpenTelemetry tracing: a span is started for
+// every handler method with a child span for each HTTP request it sends.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.TracerProvider = tp
+	}
+}
+
+// WithRateLimit limits the client, across all handlers, to requestsPerSecond
+// requests with bursts of up to burst requests. The limiter also pauses
+// when the API answers with a 429 status code. A requestsPerSecond lower
+// than or equal to 0 removes the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxInFlight limits the number of requests the client sends concurrently,
+// a value lower than 1 removes the limit.
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) {
+		if n < 1 {
+			c.inFlight = nil
+			return
+		}
+		c.inFlight = make(chan struct{}, n)
+	}
+}
+
+// WithStrictMode makes the ServiceHandler getters return request and
+// decoding errors instead of an empty value, and ServiceHandler.Get and
+// GetList fail instead of setting Service.PartialError.
+func WithStrictMode() Option {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// WithoutEagerSignIn defers the sign in from NewClient to the first request.
+func WithoutEagerSignIn() Option {
+	return func(c *Client) {
+		c.lazySignIn = true
+	}
+}
@@ -0,0 +1,47 @@
+package elestio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	var signIns atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "my-agent/1.0", r.Header.Get("User-Agent"), "expected custom user agent")
+
+		switch r.URL.Path {
+		case signInEndpoint:
+			signIns.Add(1)
+			_, _ = w.Write([]byte(`{"status":"OK","jwt":"token"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"projects":[]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	httpClient := &http.Client{}
+	policy := &RetryPolicy{MaxAttempts: 1}
+
+	c, err := NewClient("user@example.com", "key",
+		WithBaseURL(srv.URL),
+		WithHTTPClient(httpClient),
+		WithUserAgent("my-agent/1.0"),
+		WithRetryPolicy(policy),
+		WithoutEagerSignIn(),
+	)
+	require.NoError(t, err, "expected no error when creating client")
+	require.Equal(t, srv.URL, c.BaseURL)
+	require.Same(t, httpClient, c.HTTPClient)
+	require.Same(t, policy, c.RetryPolicy)
+	require.Equal(t, int32(0), signIns.Load(), "expected sign in to be deferred")
+
+	_, err = c.Project.GetList()
+	require.NoError(t, err, "expected no error when getting projects")
+	require.Equal(t, int32(1), signIns.Load(), "expected sign in on first request")
+	require.Equal(t, "token", c.token())
+}
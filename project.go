@@ -1,6 +1,7 @@
 package elestio
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -35,7 +36,15 @@ type (
 
 // Get is the method to get a project.
 func (h *ProjectHandler) Get(projectID string) (*Project, error) {
-	projects, err := h.GetList()
+	return h.GetCtx(context.Background(), projectID)
+}
+
+// GetCtx is like Get but uses ctx for the underlying HTTP requests.
+func (h *ProjectHandler) GetCtx(ctx context.Context, projectID string) (_ *Project, err error) {
+	ctx, span := h.client.startOperation(ctx, "Project.Get")
+	defer span.end(&err)
+
+	projects, err := h.GetListCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -46,14 +55,18 @@ func (h *ProjectHandler) Get(projectID string) (*Project, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("project not found")
+	return nil, fmt.Errorf("project %w", ErrNotFound)
 }
 
 // GetList is the method to get a list of projects.
 func (h *ProjectHandler) GetList() (*[]Project, error) {
-	type projetListRequest struct {
-		JWT string `json:"jwt"`
-	}
+	return h.GetListCtx(context.Background())
+}
+
+// GetListCtx is like GetList but uses ctx for the underlying HTTP requests.
+func (h *ProjectHandler) GetListCtx(ctx context.Context) (_ *[]Project, err error) {
+	ctx, span := h.client.startOperation(ctx, "Project.GetList")
+	defer span.end(&err)
 
 	type projectListResponse struct {
 		APIResponse
@@ -62,13 +75,10 @@ func (h *ProjectHandler) GetList() (*[]Project, error) {
 		} `json:"data"`
 	}
 
-	req := projetListRequest{
-		JWT: h.client.jwt,
-	}
-
 	bts, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/projects/getList", h.client.BaseURL),
-		req,
+		struct{}{},
 	)
 	if err != nil {
 		return nil, err
@@ -84,21 +94,23 @@ func (h *ProjectHandler) GetList() (*[]Project, error) {
 
 // Create creates a new project.
 func (h *ProjectHandler) Create(req CreateProjectRequest) (*Project, error) {
-	type createProjectFullRequest struct {
-		CreateProjectRequest
-		JWT string `json:"jwt"`
-	}
+	return h.CreateCtx(context.Background(), req)
+}
+
+// CreateCtx is like Create but uses ctx for the underlying HTTP requests.
+func (h *ProjectHandler) CreateCtx(ctx context.Context, req CreateProjectRequest) (_ *Project, err error) {
+	ctx, span := h.client.startOperation(ctx, "Project.Create")
+	defer span.end(&err)
 
 	type createProjectResponse struct {
 		APIResponse
 		Project Project `json:"data"`
 	}
 
-	fullReq := createProjectFullRequest{req, h.client.jwt}
-
 	bts, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/projects/addProject", h.client.BaseURL),
-		fullReq,
+		req,
 	)
 	if err != nil {
 		return nil, err
@@ -114,10 +126,17 @@ func (h *ProjectHandler) Create(req CreateProjectRequest) (*Project, error) {
 
 // Update is the method to update a project.
 func (h *ProjectHandler) Update(projectID string, req UpdateProjectRequest) (*Project, error) {
+	return h.UpdateCtx(context.Background(), projectID, req)
+}
+
+// UpdateCtx is like Update but uses ctx for the underlying HTTP requests.
+func (h *ProjectHandler) UpdateCtx(ctx context.Context, projectID string, req UpdateProjectRequest) (_ *Project, err error) {
+	ctx, span := h.client.startOperation(ctx, "Project.Update")
+	defer span.end(&err)
+
 	type updateProjectFullRequest struct {
 		UpdateProjectRequest
 		ProjectID string `json:"projectId"`
-		JWT       string `json:"jwt"`
 	}
 
 	type updateProjectResponse struct {
@@ -128,10 +147,10 @@ func (h *ProjectHandler) Update(projectID string, req UpdateProjectRequest) (*Pr
 	fullReq := updateProjectFullRequest{
 		UpdateProjectRequest: req,
 		ProjectID:            projectID,
-		JWT:                  h.client.jwt,
 	}
 
 	bts, err := h.client.sendPutRequest(
+		ctx,
 		fmt.Sprintf("%s/api/projects/editProject", h.client.BaseURL),
 		fullReq,
 	)
@@ -149,9 +168,16 @@ func (h *ProjectHandler) Update(projectID string, req UpdateProjectRequest) (*Pr
 
 // Delete is the method to delete a project.
 func (h *ProjectHandler) Delete(projectID string) error {
+	return h.DeleteCtx(context.Background(), projectID)
+}
+
+// DeleteCtx is like Delete but uses ctx for the underlying HTTP requests.
+func (h *ProjectHandler) DeleteCtx(ctx context.Context, projectID string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Project.Delete")
+	defer span.end(&err)
+
 	type deleteProjectFullRequest struct {
 		ProjectID string `json:"projectId"`
-		JWT       string `json:"jwt"`
 	}
 
 	type deleteProjectResponse struct {
@@ -160,10 +186,10 @@ func (h *ProjectHandler) Delete(projectID string) error {
 
 	req := deleteProjectFullRequest{
 		ProjectID: projectID,
-		JWT:       h.client.jwt,
 	}
 
 	bts, err := h.client.sendDeleteRequest(
+		ctx,
 		fmt.Sprintf("%s/api/projects/deleteProject", h.client.BaseURL),
 		req,
 	)
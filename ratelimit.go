@@ -0,0 +1,118 @@
+package elestio
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultThrottlePause is how long the rate limiter stops handing out
+// tokens after a 429 response without a Retry-After header.
+const defaultThrottlePause = time.Second
+
+// rateLimiter is a token bucket shared by all the handlers of a client.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// newRateLimiter returns a limiter, or nil when requestsPerSecond is not
+// positive: a nil limiter means no limit.
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if !(requestsPerSecond > 0) {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve(time.Now())
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait
+// before trying again.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// pause stops handing out tokens for d and drops the tokens accumulated
+// so far, it is called when the API answers with a 429.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.tokens = 0
+	l.last = until
+}
+
+// acquireSlot waits for a free in-flight slot when a maximum number of
+// concurrent requests is configured, and for a rate limiter token.
+// The returned function releases the slot.
+func (c *Client) acquireSlot(ctx context.Context) (func(), error) {
+	release := func() {}
+
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+			release = func() { <-c.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,86 @@
+package elestio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	l := newRateLimiter(10, 2)
+	now := l.last
+
+	require.Zero(t, l.reserve(now), "expected burst token")
+	require.Zero(t, l.reserve(now), "expected burst token")
+	require.Equal(t, 100*time.Millisecond, l.reserve(now), "expected to wait for the next token")
+	require.Zero(t, l.reserve(now.Add(100*time.Millisecond)), "expected refilled token")
+}
+
+func TestWithRateLimit_NonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		c := NewUnsignedClient(WithRateLimit(rate, 1))
+		require.Nil(t, c.limiter, "expected no limit for rate %v", rate)
+
+		release, err := c.acquireSlot(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+}
+
+func TestRateLimiter_Pause(t *testing.T) {
+	l := newRateLimiter(100, 5)
+	l.pause(time.Minute)
+
+	require.Greater(t, l.reserve(time.Now()), 59*time.Second, "expected limiter to be paused")
+}
+
+func TestClient_ThrottlePauseCappedAtMaxBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithRateLimit(100, 5),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 1, MaxBackoff: time.Second}))
+	require.Error(t, c.Service.RebootServer("1"))
+
+	require.LessOrEqual(t, c.limiter.reserve(time.Now()), time.Second, "expected the pause to be capped at MaxBackoff")
+}
+
+func TestClient_MaxInFlight(t *testing.T) {
+	var current, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithMaxInFlight(2), WithRateLimit(1000, 10))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, c.Service.RebootServer("1"))
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, peak.Load(), int32(2), "expected at most 2 concurrent requests")
+}
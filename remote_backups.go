@@ -0,0 +1,145 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// RemoteBackupOptions configures the daily remote backups of a service.
+type RemoteBackupOptions struct {
+	// Paths are the absolute paths backed up on the service.
+	Paths []string
+	// Hour is the UTC hour, from 0 to 23, at which the backup runs.
+	Hour int
+	// RetentionDays is how many days backups are kept, 0 keeps the
+	// Elestio default.
+	RetentionDays int
+}
+
+// RemoteBackupConfig is the remote backup configuration of a service.
+type RemoteBackupConfig struct {
+	Enabled bool
+	RemoteBackupOptions
+}
+
+// DefaultRemoteBackupOptions returns the options used by EnableRemoteBackups,
+// with a new Paths slice on every call so that callers can change it.
+func DefaultRemoteBackupOptions() RemoteBackupOptions {
+	return RemoteBackupOptions{Paths: []string{"/opt"}, Hour: 4}
+}
+
+// remoteBackupPathSeparator separates the paths in the backupPath field.
+const remoteBackupPathSeparator = ","
+
+// Validate checks the paths, the hour and the retention of the options.
+func (o RemoteBackupOptions) Validate() error {
+	var errs []error
+	if len(o.Paths) == 0 {
+		errs = append(errs, errors.New("at least one path is required"))
+	}
+	for _, p := range o.Paths {
+		if !path.IsAbs(p) || strings.Contains(p, remoteBackupPathSeparator) {
+			errs = append(errs, fmt.Errorf("invalid path '%s', must be absolute and cannot contain '%s'", p, remoteBackupPathSeparator))
+		}
+	}
+	if o.Hour < 0 || o.Hour > 23 {
+		errs = append(errs, fmt.Errorf("invalid hour %d, must be between 0 and 23", o.Hour))
+	}
+	if o.RetentionDays < 0 {
+		errs = append(errs, fmt.Errorf("invalid retention %d days, cannot be negative", o.RetentionDays))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EnableRemoteBackupsWithOptions is like EnableRemoteBackups but with the given options.
+func (h *ServiceHandler) EnableRemoteBackupsWithOptions(serviceId string, opts RemoteBackupOptions) error {
+	return h.EnableRemoteBackupsWithOptionsCtx(context.Background(), serviceId, opts)
+}
+
+// EnableRemoteBackupsWithOptionsCtx is like EnableRemoteBackupsWithOptions but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableRemoteBackupsWithOptionsCtx(ctx context.Context, serviceId string, opts RemoteBackupOptions) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableRemoteBackupsWithOptions")
+	defer span.end(&err)
+
+	return h.enableRemoteBackups(ctx, serviceId, opts)
+}
+
+// GetRemoteBackupConfig returns the remote backup configuration of a service.
+func (h *ServiceHandler) GetRemoteBackupConfig(serviceId string) (*RemoteBackupConfig, error) {
+	return h.GetRemoteBackupConfigCtx(context.Background(), serviceId)
+}
+
+// GetRemoteBackupConfigCtx is like GetRemoteBackupConfig but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetRemoteBackupConfigCtx(ctx context.Context, serviceId string) (_ *RemoteBackupConfig, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetRemoteBackupConfig")
+	defer span.end(&err)
+
+	req := struct {
+		ServiceID string `json:"serverID"`
+	}{
+		ServiceID: serviceId,
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/backups/GetAutoBackups", h.client.BaseURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		APIResponse
+		Data struct {
+			Enabled       NumberAsBool `json:"isActive"`
+			BackupPath    string       `json:"backupPath"`
+			BackupHour    int          `json:"backupHour"`
+			RetentionDays int          `json:"retentionDays"`
+		} `json:"data"`
+	}
+	if err = checkAPIResponse(bts, &res); err != nil {
+		return nil, err
+	}
+
+	config := RemoteBackupConfig{
+		Enabled: res.Data.Enabled == 1,
+		RemoteBackupOptions: RemoteBackupOptions{
+			Paths:         []string{},
+			Hour:          res.Data.BackupHour,
+			RetentionDays: res.Data.RetentionDays,
+		},
+	}
+	for _, p := range strings.Split(res.Data.BackupPath, remoteBackupPathSeparator) {
+		if p = strings.TrimSpace(p); p != "" {
+			config.Paths = append(config.Paths, p)
+		}
+	}
+
+	return &config, nil
+}
+
+func (h *ServiceHandler) enableRemoteBackups(ctx context.Context, serviceId string, opts RemoteBackupOptions) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid remote backup options: %w", err)
+	}
+
+	req := struct {
+		ServiceID     string `json:"serverID"`
+		BackupPath    string `json:"backupPath"`
+		BackupHour    int64  `json:"backupHour"`
+		RetentionDays int64  `json:"retentionDays,omitempty"`
+	}{
+		ServiceID:     serviceId,
+		BackupPath:    strings.Join(opts.Paths, remoteBackupPathSeparator),
+		BackupHour:    int64(opts.Hour),
+		RetentionDays: int64(opts.RetentionDays),
+	}
+
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/backups/SetupAutoBackups", h.client.BaseURL), req)
+	if err != nil {
+		return err
+	}
+
+	return checkAPIResponse(bts, nil)
+}
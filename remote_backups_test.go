@@ -0,0 +1,52 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteBackupOptions_Validate(t *testing.T) {
+	require.NoError(t, DefaultRemoteBackupOptions().Validate())
+
+	err := RemoteBackupOptions{Paths: []string{"opt", "/a,b"}, Hour: 24, RetentionDays: -1}.Validate()
+	require.EqualError(t, err, "invalid path 'opt', must be absolute and cannot contain ','\n"+
+		"invalid path '/a,b', must be absolute and cannot contain ','\n"+
+		"invalid hour 24, must be between 0 and 23\n"+
+		"invalid retention -1 days, cannot be negative")
+}
+
+func TestServiceHandler_EnableRemoteBackupsWithOptions(t *testing.T) {
+	var req map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/backups/SetupAutoBackups":
+			req = nil
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			delete(req, "jwt")
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		case "/api/backups/GetAutoBackups":
+			_, _ = fmt.Fprint(w, `{"status":"OK","data":{"isActive":1,"backupPath":"/opt,/var/lib/data","backupHour":2,"retentionDays":14}}`)
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	require.NoError(t, c.Service.EnableRemoteBackups("1"))
+	require.Equal(t, map[string]any{"serverID": "1", "backupPath": "/opt", "backupHour": float64(4)}, req)
+
+	err := c.Service.EnableRemoteBackupsWithOptions("1", RemoteBackupOptions{Paths: []string{"/opt", "/var/lib/data"}, Hour: 2, RetentionDays: 14})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"serverID": "1", "backupPath": "/opt,/var/lib/data", "backupHour": float64(2), "retentionDays": float64(14)}, req)
+
+	config, err := c.Service.GetRemoteBackupConfig("1")
+	require.NoError(t, err)
+	require.Equal(t, &RemoteBackupConfig{
+		Enabled:             true,
+		RemoteBackupOptions: RemoteBackupOptions{Paths: []string{"/opt", "/var/lib/data"}, Hour: 2, RetentionDays: 14},
+	}, config)
+}
@@ -0,0 +1,170 @@
+package elestio
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// Requests are retried on network errors and on 408 and 5xx responses
+// only when they are idempotent, a 429 response is always retried since
+// the API rejected the request before handling it.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// A value lower than 2 disables retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry,
+	// it is doubled on every following attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, including the one
+	// requested by a Retry-After header.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the delay, between 0 and 1,
+	// that is randomized to spread retries from concurrent callers.
+	Jitter float64
+
+	// IsIdempotent reports whether a request can be safely sent twice.
+	// endpoint is the URL path and action the DoActionOnServer action, if any.
+	// IsIdempotentRequest is used when nil.
+	IsIdempotent func(method, endpoint, action string) bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// Elestio exposes most read operations as POST requests, they are listed
+// here so that they can be retried like GET requests.
+var (
+	idempotentEndpoints = map[string]bool{
+		"/api/auth/checkAPIToken":        true,
+		"/api/projects/getList":          true,
+		"/api/servers/getTemplates":      true,
+		"/api/servers/getServerDetails":  true,
+		"/api/servers/getServices":       true,
+		"/api/servers/validate":          true,
+		"/api/servers/getAppCredentials": true,
+		"/api/loadBalancer/getLBDetails": true,
+		"/api/backups/GetAutoBackups":    true,
+		"/api/backups/getBackupsList":    true,
+	}
+
+	idempotentActions = map[string]bool{
+		"getAppStackConfig": true,
+		"getFirewallRules":  true,
+		"getAlerts":         true,
+		"getSnapshots":      true,
+		"SSLDomainsList":    true,
+		"SSHPubKeysList":    true,
+	}
+)
+
+// IsIdempotentRequest is the default RetryPolicy.IsIdempotent classifier.
+// It accepts GET and HEAD requests, and POST requests to the endpoints
+// and DoActionOnServer actions that only read data.
+func IsIdempotentRequest(method, endpoint, action string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		if endpoint == "/api/servers/DoActionOnServer" {
+			return idempotentActions[action]
+		}
+		return idempotentEndpoints[endpoint]
+	}
+
+	return false
+}
+
+func (p *RetryPolicy) isIdempotent(method, endpoint, action string) bool {
+	if p.IsIdempotent != nil {
+		return p.IsIdempotent(method, endpoint, action)
+	}
+
+	return IsIdempotentRequest(method, endpoint, action)
+}
+
+// canRetry reports whether another attempt is allowed after attempt.
+func (p *RetryPolicy) canRetry(attempt int) bool {
+	return attempt < p.MaxAttempts
+}
+
+// shouldRetryStatus reports whether a response with statusCode must be retried.
+func (p *RetryPolicy) shouldRetryStatus(statusCode int, idempotent bool) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return idempotent && (statusCode == http.StatusRequestTimeout || statusCode >= 500)
+}
+
+// backoff returns the delay to wait after the given attempt,
+// a Retry-After header in rsp takes precedence over the computed delay.
+// Both are capped by MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, rsp *http.Response) time.Duration {
+	if rsp != nil {
+		if d, ok := parseRetryAfter(rsp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return d
+		}
+	}
+
+	d := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d = d*(1-jitter) + d*jitter*rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+// wait sleeps for the backoff delay of attempt or until ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int, rsp *http.Response) error {
+	timer := time.NewTimer(p.backoff(attempt, rsp))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value,
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package elestio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupRetryTestCase(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewUnsignedClient()
+	c.BaseURL = srv.URL
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	return c
+}
+
+func TestRetryPolicy_RetriesReadPosts(t *testing.T) {
+	var calls atomic.Int32
+	c := setupRetryTestCase(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","data":{"projects":[]}}`))
+	})
+
+	_, err := c.Project.GetList()
+	require.NoError(t, err, "expected read request to succeed after retries")
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPolicy_DoesNotRetryMutations(t *testing.T) {
+	var calls atomic.Int32
+	c := setupRetryTestCase(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := c.Service.RebootServer("1")
+	require.Error(t, err, "expected mutating request to fail")
+	require.Equal(t, int32(1), calls.Load(), "expected mutating request to be sent once")
+
+	calls.Store(0)
+	_, err = c.Service.GetServiceSSHPublicKeys(&Service{ID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), calls.Load(), "expected read action to be retried")
+}
+
+func TestRetryPolicy_RetriesRateLimited(t *testing.T) {
+	var calls atomic.Int32
+	c := setupRetryTestCase(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	})
+
+	require.NoError(t, c.Service.RebootServer("1"), "expected throttled request to be retried")
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, d)
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
+
+func TestRetryPolicy_BackoffCapsRetryAfter(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	rsp := &http.Response{Header: http.Header{"Retry-After": []string{"7200"}}}
+	require.Equal(t, 10*time.Second, p.backoff(1, rsp))
+
+	rsp.Header.Set("Retry-After", "3")
+	require.Equal(t, 3*time.Second, p.backoff(1, rsp))
+}
@@ -1,9 +1,12 @@
 package elestio
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // ServiceHandler is the client handler for service endpoints.
@@ -25,8 +28,9 @@ const (
 	ServiceFirewallRuleTypeOutput string = "OUTPUT"
 
 	// Firewall rule protocols
-	ServiceFirewallRuleProtocolTCP string = "tcp"
-	ServiceFirewallRuleProtocolUDP string = "udp"
+	ServiceFirewallRuleProtocolTCP  string = "tcp"
+	ServiceFirewallRuleProtocolUDP  string = "udp"
+	ServiceFirewallRuleProtocolICMP string = "icmp"
 )
 
 type (
@@ -135,6 +139,10 @@ type (
 		DatabaseAdminCommand                        string       `json:"managedDBCLI"`
 		DatabaseAdminPort                           string       `json:"managedDBPort"`
 		AlertsEnabled                               NumberAsBool `json:"isAlertsActivated"`
+
+		// PartialError lists the sub-resources that could not be fetched,
+		// it is nil when all of them were fetched or in strict mode.
+		PartialError *PartialError `json:"-"`
 	}
 
 	ValidateConfigRequest struct {
@@ -162,11 +170,19 @@ type (
 )
 
 func (h *ServiceHandler) GetTemplatesList() ([]*Template, error) {
+	return h.GetTemplatesListCtx(context.Background())
+}
+
+// GetTemplatesListCtx is like GetTemplatesList but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetTemplatesListCtx(ctx context.Context) (_ []*Template, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetTemplatesList")
+	defer span.end(&err)
+
 	type getTemplatesListResponse struct {
 		Templates []Template `json:"instances"`
 	}
 
-	bts, err := h.client.sendGetRequest(fmt.Sprintf("%s/api/servers/getTemplates", h.client.BaseURL), nil)
+	bts, err := h.client.sendGetRequest(ctx, fmt.Sprintf("%s/api/servers/getTemplates", h.client.BaseURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +193,7 @@ func (h *ServiceHandler) GetTemplatesList() ([]*Template, error) {
 	}
 
 	if len(res.Templates) == 0 {
-		return nil, fmt.Errorf("templates not found")
+		return nil, fmt.Errorf("templates %w", ErrNotFound)
 	}
 
 	var templates []*Template
@@ -190,11 +206,20 @@ func (h *ServiceHandler) GetTemplatesList() ([]*Template, error) {
 	return templates, nil
 }
 
-func (h *ServiceHandler) Get(projectID, serviceID string) (*Service, error) {
+// Get returns a service with the sub-resources selected by opts,
+// all of them by default.
+func (h *ServiceHandler) Get(projectID, serviceID string, opts ...GetOption) (*Service, error) {
+	return h.GetCtx(context.Background(), projectID, serviceID, opts...)
+}
+
+// GetCtx is like Get but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetCtx(ctx context.Context, projectID, serviceID string, opts ...GetOption) (_ *Service, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.Get")
+	defer span.end(&err)
+
 	type getServiceRequest struct {
 		ProjectID string `json:"projectID"`
 		ServiceID string `json:"vmID"`
-		JWT       string `json:"jwt"`
 	}
 
 	type getServiceResponse struct {
@@ -205,10 +230,10 @@ func (h *ServiceHandler) Get(projectID, serviceID string) (*Service, error) {
 	req := getServiceRequest{
 		ProjectID: projectID,
 		ServiceID: serviceID,
-		JWT:       h.client.jwt,
 	}
 
 	bts, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/servers/getServerDetails", h.client.BaseURL),
 		req,
 	)
@@ -222,18 +247,27 @@ func (h *ServiceHandler) Get(projectID, serviceID string) (*Service, error) {
 	}
 
 	if len(res.Services) == 0 {
-		return nil, fmt.Errorf("service not found")
+		return nil, fmt.Errorf("service %w", ErrNotFound)
 	}
 
-	return h.formatServiceForClient(&res.Services[0])
+	return h.formatServiceForClient(ctx, &res.Services[0], newGetOptions(opts))
 }
 
-func (h *ServiceHandler) GetList(projectID string) ([]*Service, error) {
+// GetList returns the services of a project with the sub-resources
+// selected by opts, all of them by default.
+func (h *ServiceHandler) GetList(projectID string, opts ...GetOption) ([]*Service, error) {
+	return h.GetListCtx(context.Background(), projectID, opts...)
+}
+
+// GetListCtx is like GetList but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetListCtx(ctx context.Context, projectID string, opts ...GetOption) (_ []*Service, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetList")
+	defer span.end(&err)
+
 	type getListServiceRequest struct {
 		ProjectID       string `json:"projectId"`
 		AppID           string `json:"appid"`
 		IsActiveService bool   `json:"isActiveService"`
-		JWT             string `json:"jwt"`
 	}
 
 	type getListServiceResponse struct {
@@ -245,10 +279,10 @@ func (h *ServiceHandler) GetList(projectID string) ([]*Service, error) {
 		ProjectID:       projectID,
 		AppID:           "",
 		IsActiveService: true,
-		JWT:             h.client.jwt,
 	}
 
 	bts, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/servers/getServices", h.client.BaseURL),
 		req,
 	)
@@ -261,24 +295,23 @@ func (h *ServiceHandler) GetList(projectID string) ([]*Service, error) {
 		return nil, err
 	}
 
-	var services []*Service
-	for i := range res.Services {
-		s, err := h.formatServiceForClient(&res.Services[i])
-		if err != nil {
-			return nil, err
-		}
-		services = append(services, s)
-	}
-
-	return services, nil
+	return h.formatServicesForClient(ctx, res.Services, newGetOptions(opts))
 }
 
 func (h *ServiceHandler) ValidateConfig(req ValidateConfigRequest) (isValid bool, err error) {
+	return h.ValidateConfigCtx(context.Background(), req)
+}
+
+// ValidateConfigCtx is like ValidateConfig but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) ValidateConfigCtx(ctx context.Context, req ValidateConfigRequest) (isValid bool, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.ValidateConfig")
+	defer span.end(&err)
+
 	type validateConfigResponse struct {
 		APIResponse
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/validate", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/validate", h.client.BaseURL), req)
 	if err != nil {
 		return false, err
 	}
@@ -292,13 +325,20 @@ func (h *ServiceHandler) ValidateConfig(req ValidateConfigRequest) (isValid bool
 }
 
 func (h *ServiceHandler) Create(req CreateServiceRequest) (*Service, error) {
+	return h.CreateCtx(context.Background(), req)
+}
+
+// CreateCtx is like Create but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) CreateCtx(ctx context.Context, req CreateServiceRequest) (_ *Service, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.Create")
+	defer span.end(&err)
+
 	type createServiceFullRequest struct {
 		CreateServiceRequest
 		Data                  string `json:"data"`
 		AppID                 string `json:"appid"`
 		DeploymentServiceType string `json:"deploymentServiceType"` // "normal"
 		ServiceType           string `json:"serviceType"`           // "service"
-		JWT                   string `json:"jwt"`
 	}
 
 	if req.CreatedFrom == "" {
@@ -311,10 +351,10 @@ func (h *ServiceHandler) Create(req CreateServiceRequest) (*Service, error) {
 		AppID:                 "",
 		DeploymentServiceType: "normal",
 		ServiceType:           "Service",
-		JWT:                   h.client.jwt,
 	}
 
 	bts, err := h.client.sendPostRequest(
+		ctx,
 		fmt.Sprintf("%s/api/servers/createServer", h.client.BaseURL),
 		fullReq,
 	)
@@ -333,15 +373,22 @@ func (h *ServiceHandler) Create(req CreateServiceRequest) (*Service, error) {
 		return nil, err
 	}
 
-	return h.formatServiceForClient(&res.Data[0])
+	return h.formatServiceForClient(ctx, &res.Data[0], newGetOptions(nil))
 }
 
 func (h *ServiceHandler) Delete(projectID, serviceID string, keepBackups bool) error {
+	return h.DeleteCtx(context.Background(), projectID, serviceID, keepBackups)
+}
+
+// DeleteCtx is like Delete but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DeleteCtx(ctx context.Context, projectID, serviceID string, keepBackups bool) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.Delete")
+	defer span.end(&err)
+
 	type deleteServiceRequest struct {
 		ProjectID       string `json:"projectID"`
 		ServiceID       string `json:"vmID"`
 		IsWithoutBackup bool   `json:"isDeleteServiceWithBackup"`
-		JWT             string `json:"jwt"`
 	}
 
 	type deleteServiceResponse struct {
@@ -352,10 +399,9 @@ func (h *ServiceHandler) Delete(projectID, serviceID string, keepBackups bool) e
 		ProjectID:       projectID,
 		ServiceID:       serviceID,
 		IsWithoutBackup: !keepBackups,
-		JWT:             h.client.jwt,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/deleteServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/deleteServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -369,19 +415,25 @@ func (h *ServiceHandler) Delete(projectID, serviceID string, keepBackups bool) e
 }
 
 func (h *ServiceHandler) UpdateVersion(serviceId string, newVersion string) error {
+	return h.UpdateVersionCtx(context.Background(), serviceId, newVersion)
+}
+
+// UpdateVersionCtx is like UpdateVersion but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateVersionCtx(ctx context.Context, serviceId string, newVersion string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateVersion")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 		Version   string `json:"versionTag"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "softwareChangeSelectedVersion",
 		Version:   newVersion,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -393,8 +445,15 @@ func (h *ServiceHandler) UpdateVersion(serviceId string, newVersion string) erro
 // You can only upgrade the server type, not downgrade.
 // The service will reboot in a few minutes.
 func (h *ServiceHandler) UpdateServerType(serviceId string, newServerType string, providerName string, datacenter string) error {
+	return h.UpdateServerTypeCtx(context.Background(), serviceId, newServerType, providerName, datacenter)
+}
+
+// UpdateServerTypeCtx is like UpdateServerType but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateServerTypeCtx(ctx context.Context, serviceId string, newServerType string, providerName string, datacenter string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateServerType")
+	defer span.end(&err)
+
 	req := struct {
-		JWT               string `json:"jwt"`
 		ServiceID         string `json:"vmID"`
 		Action            string `json:"action"`
 		ServerType        string `json:"newType"`
@@ -402,7 +461,6 @@ func (h *ServiceHandler) UpdateServerType(serviceId string, newServerType string
 		Datacenter        string `json:"region"`
 		UpgradeCPURAMOnly bool   `json:"upgradeCPURAMOnly"`
 	}{
-		JWT:               h.client.jwt,
 		ServiceID:         serviceId,
 		Action:            "changeType",
 		ServerType:        newServerType,
@@ -411,7 +469,7 @@ func (h *ServiceHandler) UpdateServerType(serviceId string, newServerType string
 		UpgradeCPURAMOnly: false,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -420,83 +478,93 @@ func (h *ServiceHandler) UpdateServerType(serviceId string, newServerType string
 }
 
 func (h *ServiceHandler) DisableAppAutoUpdates(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "appAutoUpdateDisable")
+	return h.DisableAppAutoUpdatesCtx(context.Background(), serviceId)
+}
+
+// DisableAppAutoUpdatesCtx is like DisableAppAutoUpdates but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableAppAutoUpdatesCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableAppAutoUpdates")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "appAutoUpdateDisable")
 }
 
 func (h *ServiceHandler) EnableAppAutoUpdates(serviceId string) error {
-	req := struct {
-		JWT             string `json:"jwt"`
-		ServiceID       string `json:"vmID"`
-		Action          string `json:"action"`
-		UpdateDayOfWeek string `json:"appAutoUpdateDayOfWeek"`
-		UpdateHour      string `json:"appAutoUpdateHour"`
-		UpdateMinute    string `json:"appAutoUpdateMinute"`
-	}{
-		JWT:             h.client.jwt,
-		ServiceID:       serviceId,
-		Action:          "appAutoUpdateEnable",
-		UpdateDayOfWeek: "0",
-		UpdateHour:      "1",
-		UpdateMinute:    "00",
-	}
+	return h.EnableAppAutoUpdatesCtx(context.Background(), serviceId)
+}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
-	if err != nil {
-		return err
-	}
+// EnableAppAutoUpdatesCtx is like EnableAppAutoUpdates but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableAppAutoUpdatesCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableAppAutoUpdates")
+	defer span.end(&err)
 
-	return checkAPIResponse(bts, nil)
+	return h.enableAppAutoUpdates(ctx, serviceId, DefaultAppAutoUpdateSchedule())
 }
 
 func (h *ServiceHandler) DisableSystemAutoUpdates(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "systemAutoUpdateDisable")
+	return h.DisableSystemAutoUpdatesCtx(context.Background(), serviceId)
+}
+
+// DisableSystemAutoUpdatesCtx is like DisableSystemAutoUpdates but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableSystemAutoUpdatesCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableSystemAutoUpdates")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "systemAutoUpdateDisable")
 }
 
 func (h *ServiceHandler) EnableSystemAutoUpdates(serviceId string, isSystemAutoUpdatesSecurityPatchesOnlyEnabled bool) error {
-	req := struct {
-		JWT                                           string `json:"jwt"`
-		ServiceID                                     string `json:"vmID"`
-		Action                                        string `json:"action"`
-		UpdateDayOfWeek                               string `json:"systemAutoUpdateRebootDayOfWeek"`
-		UpdateHour                                    string `json:"systemAutoUpdateRebootHour"`
-		UpdateMinute                                  string `json:"systemAutoUpdateRebootMinute"`
-		IsSystemAutoUpdatesSecurityPatchesOnlyEnabled bool   `json:"systemAutoUpdateSecurityPatchesOnly"`
-	}{
-		JWT:             h.client.jwt,
-		ServiceID:       serviceId,
-		Action:          "systemAutoUpdateEnable",
-		UpdateDayOfWeek: "0",
-		UpdateHour:      "5",
-		UpdateMinute:    "00",
-		IsSystemAutoUpdatesSecurityPatchesOnlyEnabled: isSystemAutoUpdatesSecurityPatchesOnlyEnabled,
-	}
+	return h.EnableSystemAutoUpdatesCtx(context.Background(), serviceId, isSystemAutoUpdatesSecurityPatchesOnlyEnabled)
+}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
-	if err != nil {
-		return err
-	}
+// EnableSystemAutoUpdatesCtx is like EnableSystemAutoUpdates but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableSystemAutoUpdatesCtx(ctx context.Context, serviceId string, isSystemAutoUpdatesSecurityPatchesOnlyEnabled bool) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableSystemAutoUpdates")
+	defer span.end(&err)
 
-	return checkAPIResponse(bts, nil)
+	return h.enableSystemAutoUpdates(ctx, serviceId, isSystemAutoUpdatesSecurityPatchesOnlyEnabled, DefaultSystemAutoUpdateSchedule())
 }
 
 func (h *ServiceHandler) DisableBackups(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "disableBackup")
+	return h.DisableBackupsCtx(context.Background(), serviceId)
+}
+
+// DisableBackupsCtx is like DisableBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableBackupsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableBackups")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "disableBackup")
 }
 
 func (h *ServiceHandler) EnableBackups(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "enableBackup")
+	return h.EnableBackupsCtx(context.Background(), serviceId)
+}
+
+// EnableBackupsCtx is like EnableBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableBackupsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableBackups")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "enableBackup")
 }
 
 func (h *ServiceHandler) DisableRemoteBackups(serviceId string) error {
+	return h.DisableRemoteBackupsCtx(context.Background(), serviceId)
+}
+
+// DisableRemoteBackupsCtx is like DisableRemoteBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableRemoteBackupsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableRemoteBackups")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"serverID"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/backups/DisableAutoBackups", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/backups/DisableAutoBackups", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -505,77 +573,77 @@ func (h *ServiceHandler) DisableRemoteBackups(serviceId string) error {
 }
 
 func (h *ServiceHandler) EnableRemoteBackups(serviceId string) error {
-	req := struct {
-		JWT        string `json:"jwt"`
-		ServiceID  string `json:"serverID"`
-		BackupPath string `json:"backupPath"`
-		BackupHour int64  `json:"backupHour"`
-	}{
-		JWT:        h.client.jwt,
-		ServiceID:  serviceId,
-		BackupPath: "/opt",
-		BackupHour: 4,
-	}
+	return h.EnableRemoteBackupsCtx(context.Background(), serviceId)
+}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/backups/SetupAutoBackups", h.client.BaseURL), req)
-	if err != nil {
-		return err
-	}
+// EnableRemoteBackupsCtx is like EnableRemoteBackups but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableRemoteBackupsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableRemoteBackups")
+	defer span.end(&err)
 
-	return checkAPIResponse(bts, nil)
+	return h.enableRemoteBackups(ctx, serviceId, DefaultRemoteBackupOptions())
 }
 
 func (h *ServiceHandler) DisableAlerts(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "disableAlerts")
+	return h.DisableAlertsCtx(context.Background(), serviceId)
+}
+
+// DisableAlertsCtx is like DisableAlerts but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableAlertsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableAlerts")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "disableAlerts")
 }
 
 func (h *ServiceHandler) EnableAlerts(serviceId string) error {
-	req := struct {
-		JWT                 string `json:"jwt"`
-		ServiceID           string `json:"vmID"`
-		Action              string `json:"action"`
-		MonitCycleInSeconds int64  `json:"monitCycleInSeconds"`
-		Rules               string `json:"rules"`
-	}{
-		JWT:                 h.client.jwt,
-		ServiceID:           serviceId,
-		Action:              "enableAlerts",
-		MonitCycleInSeconds: 60,
-		Rules:               "[{\"parameter\":\"CPU\",\"value\":90,\"cycles\":15,\"unit\":\"%\"},{\"parameter\":\"MEMORY\",\"value\":90,\"cycles\":15,\"unit\":\"%\"},{\"parameter\":\"SWAP\",\"value\":75,\"cycles\":15,\"unit\":\"%\"},{\"parameter\":\"SPACE\",\"value\":80,\"cycles\":15,\"unit\":\"%\"},{\"parameter\":\"INODE\",\"value\":80,\"cycles\":15,\"unit\":\"%\"},{\"parameter\":\"READ_RATE\",\"value\":20,\"cycles\":15,\"unit\":\"MB/s\"},{\"parameter\":\"WRITE_RATE\",\"value\":20,\"cycles\":15,\"unit\":\"MB/s\"},{\"parameter\":\"SATURATION\",\"value\":90,\"cycles\":15,\"unit\":\"%\"},{\"parameter\":\"DOWNLOAD\",\"value\":25,\"cycles\":15,\"unit\":\"MB/s\"},{\"parameter\":\"UPLOAD\",\"value\":25,\"cycles\":15,\"unit\":\"MB/s\"}]",
-	}
+	return h.EnableAlertsCtx(context.Background(), serviceId)
+}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
-	if err != nil {
-		return err
-	}
+// EnableAlertsCtx is like EnableAlerts but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableAlertsCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableAlerts")
+	defer span.end(&err)
 
-	return checkAPIResponse(bts, nil)
+	return h.enableAlerts(ctx, serviceId, DefaultAlertCycle, DefaultAlertRules())
 }
 
 func (h *ServiceHandler) DisableFirewall(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "disableFirewall")
+	return h.DisableFirewallCtx(context.Background(), serviceId)
+}
+
+// DisableFirewallCtx is like DisableFirewall but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DisableFirewallCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DisableFirewall")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "disableFirewall")
 }
 
 func (h *ServiceHandler) EnableFirewallWithRules(serviceId string, rules []ServiceFirewallRule) error {
-	for _, rule := range rules {
-		if rule.Type != ServiceFirewallRuleTypeInput && rule.Type != ServiceFirewallRuleTypeOutput {
-			return fmt.Errorf("invalid rule type '%s': only '%s' and '%s' are supported", rule.Type, ServiceFirewallRuleTypeInput, ServiceFirewallRuleTypeOutput)
-		}
+	return h.EnableFirewallWithRulesCtx(context.Background(), serviceId, rules)
+}
+
+// EnableFirewallWithRulesCtx is like EnableFirewallWithRules but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) EnableFirewallWithRulesCtx(ctx context.Context, serviceId string, rules []ServiceFirewallRule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.EnableFirewallWithRules")
+	defer span.end(&err)
+
+	if err = ValidateFirewallRules(rules); err != nil {
+		return err
 	}
 
 	req := struct {
-		JWT       string                `json:"jwt"`
 		ServiceID string                `json:"vmID"`
 		Action    string                `json:"action"`
 		Rules     []ServiceFirewallRule `json:"rules"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "enableFirewall",
 		Rules:     rules,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -584,25 +652,29 @@ func (h *ServiceHandler) EnableFirewallWithRules(serviceId string, rules []Servi
 }
 
 func (h *ServiceHandler) UpdateFirewallRules(serviceId string, rules []ServiceFirewallRule) error {
-	for _, rule := range rules {
-		if rule.Type != ServiceFirewallRuleTypeInput && rule.Type != ServiceFirewallRuleTypeOutput {
-			return fmt.Errorf("invalid rule type '%s': only '%s' and '%s' are supported", rule.Type, ServiceFirewallRuleTypeInput, ServiceFirewallRuleTypeOutput)
-		}
+	return h.UpdateFirewallRulesCtx(context.Background(), serviceId, rules)
+}
+
+// UpdateFirewallRulesCtx is like UpdateFirewallRules but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateFirewallRulesCtx(ctx context.Context, serviceId string, rules []ServiceFirewallRule) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateFirewallRules")
+	defer span.end(&err)
+
+	if err = ValidateFirewallRules(rules); err != nil {
+		return err
 	}
 
 	req := struct {
-		JWT       string                `json:"jwt"`
 		ServiceID string                `json:"vmID"`
 		Action    string                `json:"action"`
 		Rules     []ServiceFirewallRule `json:"rules"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "updateFirewall",
 		Rules:     rules,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -611,19 +683,25 @@ func (h *ServiceHandler) UpdateFirewallRules(serviceId string, rules []ServiceFi
 }
 
 func (h *ServiceHandler) AddCustomDomainName(serviceId string, domain string) error {
+	return h.AddCustomDomainNameCtx(context.Background(), serviceId, domain)
+}
+
+// AddCustomDomainNameCtx is like AddCustomDomainName but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) AddCustomDomainNameCtx(ctx context.Context, serviceId string, domain string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.AddCustomDomainName")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 		Domain    string `json:"domain"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "SSLDomainsAdd",
 		Domain:    domain,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -632,19 +710,25 @@ func (h *ServiceHandler) AddCustomDomainName(serviceId string, domain string) er
 }
 
 func (h *ServiceHandler) RemoveCustomDomainName(serviceId string, domain string) error {
+	return h.RemoveCustomDomainNameCtx(context.Background(), serviceId, domain)
+}
+
+// RemoveCustomDomainNameCtx is like RemoveCustomDomainName but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) RemoveCustomDomainNameCtx(ctx context.Context, serviceId string, domain string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.RemoveCustomDomainName")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 		Domain    string `json:"domain"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "SSLDomainsRemove",
 		Domain:    domain,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -653,21 +737,27 @@ func (h *ServiceHandler) RemoveCustomDomainName(serviceId string, domain string)
 }
 
 func (h *ServiceHandler) AddSSHPublicKey(serviceId string, name string, key string) error {
+	return h.AddSSHPublicKeyCtx(context.Background(), serviceId, name, key)
+}
+
+// AddSSHPublicKeyCtx is like AddSSHPublicKey but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) AddSSHPublicKeyCtx(ctx context.Context, serviceId string, name string, key string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.AddSSHPublicKey")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 		Name      string `json:"name"`
 		Key       string `json:"key"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "SSHPubKeysAdd",
 		Name:      name,
 		Key:       key,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -676,19 +766,25 @@ func (h *ServiceHandler) AddSSHPublicKey(serviceId string, name string, key stri
 }
 
 func (h *ServiceHandler) RemoveSSHPublicKey(serviceId string, name string) error {
+	return h.RemoveSSHPublicKeyCtx(context.Background(), serviceId, name)
+}
+
+// RemoveSSHPublicKeyCtx is like RemoveSSHPublicKey but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) RemoveSSHPublicKeyCtx(ctx context.Context, serviceId string, name string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.RemoveSSHPublicKey")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 		Name      string `json:"deleteParams"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    "SSHPubKeysRemove",
 		Name:      name,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -697,21 +793,35 @@ func (h *ServiceHandler) RemoveSSHPublicKey(serviceId string, name string) error
 }
 
 func (h *ServiceHandler) RebootServer(serviceId string) error {
-	return h.DoActionOnServer(serviceId, "reboot")
+	return h.RebootServerCtx(context.Background(), serviceId)
+}
+
+// RebootServerCtx is like RebootServer but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) RebootServerCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.RebootServer")
+	defer span.end(&err)
+
+	return h.DoActionOnServerCtx(ctx, serviceId, "reboot")
 }
 
 func (h *ServiceHandler) DoActionOnServer(serviceId string, action string) error {
+	return h.DoActionOnServerCtx(context.Background(), serviceId, action)
+}
+
+// DoActionOnServerCtx is like DoActionOnServer but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) DoActionOnServerCtx(ctx context.Context, serviceId string, action string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.DoActionOnServer")
+	defer span.end(&err)
+
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: serviceId,
 		Action:    action,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
 		return err
 	}
@@ -720,56 +830,116 @@ func (h *ServiceHandler) DoActionOnServer(serviceId string, action string) error
 }
 
 func (h *ServiceHandler) GetServiceEnv(service *Service) (*map[string]string, error) {
-	envMap, emptyEnvMap := make(map[string]string), make(map[string]string)
+	return h.GetServiceEnvCtx(context.Background(), service)
+}
+
+// GetServiceEnvCtx is like GetServiceEnv but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceEnvCtx(ctx context.Context, service *Service) (_ *map[string]string, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceEnv")
+	defer span.end(&err)
+
+	emptyEnvMap := make(map[string]string)
 
 	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
 		return &emptyEnvMap, nil
 	}
 
+	config, err := h.getAppStackConfig(ctx, service)
+	if err != nil {
+		return strictOrEmpty(ctx, h.client, &emptyEnvMap, err)
+	}
+
+	// Lines that cannot be parsed are only reported in strict mode
+	dotenv, err := ParseDotenv(config.Env)
+	envMap := dotenv.Map()
+	if err != nil && h.client.isStrict(ctx) {
+		return &envMap, fmt.Errorf("failed to parse service env: %w", err)
+	}
+
+	return &envMap, nil
+}
+
+// appStackConfig is the configuration of the application of a service.
+type appStackConfig struct {
+	// Env is the content of the .env file.
+	Env string `json:"envResult"`
+	// Compose is the content of the docker-compose.yml file.
+	Compose string `json:"composeResult"`
+}
+
+// getAppStackConfig fetches the application configuration of a deployed service.
+func (h *ServiceHandler) getAppStackConfig(ctx context.Context, service *Service) (*appStackConfig, error) {
 	req := struct {
-		JWT        string `json:"jwt"`
 		ProjectID  string `json:"projectID"`
 		ServiceID  string `json:"vmID"`
 		TemplateID int64  `json:"templateID"`
 		Action     string `json:"action"`
 	}{
-		JWT:        h.client.jwt,
 		ProjectID:  service.ProjectID,
 		ServiceID:  service.ID,
 		TemplateID: service.TemplateID,
 		Action:     "getAppStackConfig",
 	}
 
-	res := struct {
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
 		APIResponse
-		Data struct {
-			Env string `json:"envResult"`
-		} `json:"data"`
-	}{}
+		Data appStackConfig `json:"data"`
+	}
+	if err = checkAPIResponse(bts, &res); err != nil {
+		return nil, err
+	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
-	if err != nil {
-		return &emptyEnvMap, nil
+	return &res.Data, nil
+}
+
+// updateAppStackConfig writes the application configuration of a service,
+// config must hold both files as the API replaces them together.
+func (h *ServiceHandler) updateAppStackConfig(ctx context.Context, service *Service, config appStackConfig) error {
+	// An empty compose would wipe the application of the service
+	if strings.TrimSpace(config.Compose) == "" {
+		return fmt.Errorf("refusing to update service %s without its docker-compose file", service.ID)
 	}
 
-	if err := checkAPIResponse(bts, &res); err != nil {
-		return &emptyEnvMap, nil
+	req := struct {
+		ProjectID  string `json:"projectID"`
+		ServiceID  string `json:"vmID"`
+		TemplateID int64  `json:"templateID"`
+		Action     string `json:"action"`
+		Env        string `json:"envData"`
+		Compose    string `json:"composeData"`
+	}{
+		ProjectID:  service.ProjectID,
+		ServiceID:  service.ID,
+		TemplateID: service.TemplateID,
+		Action:     "updateAppStackConfig",
+		Env:        config.Env,
+		Compose:    config.Compose,
 	}
 
-	envs := strings.Split(res.Data.Env, "\n")
-	for _, env := range envs {
-		envSplit := strings.Split(env, "=")
-		if len(envSplit) == 2 {
-			envMap[envSplit[0]] = envSplit[1]
-		}
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	if err != nil {
+		return err
 	}
 
-	return &envMap, nil
+	return checkAPIResponse(bts, nil)
 }
 
 // GetServiceAdmin returns the admin credentials for a service,
 // returns an empty ServiceAdmin if the service is not deployed.
 func (h *ServiceHandler) GetServiceAdmin(service *Service) (*ServiceAdmin, error) {
+	return h.GetServiceAdminCtx(context.Background(), service)
+}
+
+// GetServiceAdminCtx is like GetServiceAdmin but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceAdminCtx(ctx context.Context, service *Service) (_ *ServiceAdmin, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceAdmin")
+	defer span.end(&err)
+
 	serviceAdmin, emptyServiceAdmin := ServiceAdmin{}, ServiceAdmin{}
 
 	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
@@ -777,7 +947,6 @@ func (h *ServiceHandler) GetServiceAdmin(service *Service) (*ServiceAdmin, error
 	}
 
 	req := struct {
-		JWT               string `json:"jwt"`
 		ProjectID         string `json:"projectID"`
 		ServiceID         string `json:"vmID"`
 		AppId             string `json:"appId"`
@@ -785,7 +954,6 @@ func (h *ServiceHandler) GetServiceAdmin(service *Service) (*ServiceAdmin, error
 		AdminExternalPort int64  `json:"srvPort"`
 		AdminInternalPort int64  `json:"targetPort"`
 	}{
-		JWT:               h.client.jwt,
 		ProjectID:         service.ProjectID,
 		ServiceID:         service.ID,
 		AppId:             "CloudVM",
@@ -794,13 +962,13 @@ func (h *ServiceHandler) GetServiceAdmin(service *Service) (*ServiceAdmin, error
 		AdminInternalPort: service.AdminInternalPort,
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/getAppCredentials", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/getAppCredentials", h.client.BaseURL), req)
 	if err != nil {
-		return &emptyServiceAdmin, nil
+		return strictOrEmpty(ctx, h.client, &emptyServiceAdmin, err)
 	}
 
 	if err := checkAPIResponse(bts, &serviceAdmin); err != nil {
-		return &emptyServiceAdmin, nil
+		return strictOrEmpty(ctx, h.client, &emptyServiceAdmin, err)
 	}
 
 	return &serviceAdmin, nil
@@ -809,6 +977,14 @@ func (h *ServiceHandler) GetServiceAdmin(service *Service) (*ServiceAdmin, error
 // GetServiceDatabaseAdmin returns the database admin credentials for a service,
 // returns an empty ServiceDatabaseAdmin if the service is not deployed.
 func (h *ServiceHandler) GetServiceDatabaseAdmin(service *Service) (*ServiceDatabaseAdmin, error) {
+	return h.GetServiceDatabaseAdminCtx(context.Background(), service)
+}
+
+// GetServiceDatabaseAdminCtx is like GetServiceDatabaseAdmin but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceDatabaseAdminCtx(ctx context.Context, service *Service) (_ *ServiceDatabaseAdmin, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceDatabaseAdmin")
+	defer span.end(&err)
+
 	databaseAdmin, emptyDatabaseAdmin := ServiceDatabaseAdmin{}, ServiceDatabaseAdmin{}
 
 	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
@@ -820,7 +996,6 @@ func (h *ServiceHandler) GetServiceDatabaseAdmin(service *Service) (*ServiceData
 	}
 
 	req := struct {
-		JWT               string `json:"jwt"`
 		ProjectID         string `json:"projectID"`
 		ServiceID         string `json:"vmID"`
 		AppId             string `json:"appId"`
@@ -829,7 +1004,6 @@ func (h *ServiceHandler) GetServiceDatabaseAdmin(service *Service) (*ServiceData
 		AdminInternalPort int64  `json:"targetPort"`
 		Mode              string `json:"mode"`
 	}{
-		JWT:               h.client.jwt,
 		ProjectID:         service.ProjectID,
 		ServiceID:         service.ID,
 		AppId:             "CloudVM",
@@ -840,13 +1014,13 @@ func (h *ServiceHandler) GetServiceDatabaseAdmin(service *Service) (*ServiceData
 	}
 
 	res := struct{ ServiceAdmin }{}
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/getAppCredentials", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/getAppCredentials", h.client.BaseURL), req)
 	if err != nil {
-		return &emptyDatabaseAdmin, nil
+		return strictOrEmpty(ctx, h.client, &emptyDatabaseAdmin, err)
 	}
 
 	if err := checkAPIResponse(bts, &res); err != nil {
-		return &emptyDatabaseAdmin, nil
+		return strictOrEmpty(ctx, h.client, &emptyDatabaseAdmin, err)
 	}
 
 	databaseAdmin.Host = service.CNAME
@@ -863,6 +1037,14 @@ func (h *ServiceHandler) GetServiceDatabaseAdmin(service *Service) (*ServiceData
 
 // GetServiceFirewallRules returns all firewall rules configured for a service (INPUT and OUTPUT)
 func (h *ServiceHandler) GetServiceFirewallRules(service *Service) (*[]ServiceFirewallRule, error) {
+	return h.GetServiceFirewallRulesCtx(context.Background(), service)
+}
+
+// GetServiceFirewallRulesCtx is like GetServiceFirewallRules but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceFirewallRulesCtx(ctx context.Context, service *Service) (_ *[]ServiceFirewallRule, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceFirewallRules")
+	defer span.end(&err)
+
 	var empty []ServiceFirewallRule
 
 	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
@@ -874,18 +1056,16 @@ func (h *ServiceHandler) GetServiceFirewallRules(service *Service) (*[]ServiceFi
 	}
 
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: service.ID,
 		Action:    "getFirewallRules",
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
-		return &empty, nil
+		return strictOrEmpty(ctx, h.client, &empty, err)
 	}
 
 	res := struct {
@@ -894,7 +1074,7 @@ func (h *ServiceHandler) GetServiceFirewallRules(service *Service) (*[]ServiceFi
 	}{}
 
 	if err := checkAPIResponse(bts, &res); err != nil {
-		return &empty, nil
+		return strictOrEmpty(ctx, h.client, &empty, err)
 	}
 
 	return &res.Rules, nil
@@ -902,6 +1082,14 @@ func (h *ServiceHandler) GetServiceFirewallRules(service *Service) (*[]ServiceFi
 
 // GetServiceCustomDomainNames returns the custom domain names configured for a service
 func (h *ServiceHandler) GetServiceCustomDomainNames(service *Service) (*[]string, error) {
+	return h.GetServiceCustomDomainNamesCtx(context.Background(), service)
+}
+
+// GetServiceCustomDomainNamesCtx is like GetServiceCustomDomainNames but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceCustomDomainNamesCtx(ctx context.Context, service *Service) (_ *[]string, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceCustomDomainNames")
+	defer span.end(&err)
+
 	var empty []string
 
 	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
@@ -909,23 +1097,21 @@ func (h *ServiceHandler) GetServiceCustomDomainNames(service *Service) (*[]strin
 	}
 
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: service.ID,
 		Action:    "SSLDomainsList",
 	}
 
-	bts, err := h.client.sendPostRequestRaw(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequestRaw(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
-		return &empty, nil
+		return strictOrEmpty(ctx, h.client, &empty, err)
 	}
 
 	var customDomainNames []string
 	if err := json.Unmarshal(bts, &customDomainNames); err != nil {
-		return &empty, nil
+		return strictOrEmpty(ctx, h.client, &empty, err)
 	}
 
 	// Remove the default service CNAME from the list of custom domain names
@@ -936,6 +1122,14 @@ func (h *ServiceHandler) GetServiceCustomDomainNames(service *Service) (*[]strin
 
 // GetServiceSSHPublicKeys returns the ssh public keys configured for a service
 func (h *ServiceHandler) GetServiceSSHPublicKeys(service *Service) (*[]ServiceSSHPublicKey, error) {
+	return h.GetServiceSSHPublicKeysCtx(context.Background(), service)
+}
+
+// GetServiceSSHPublicKeysCtx is like GetServiceSSHPublicKeys but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceSSHPublicKeysCtx(ctx context.Context, service *Service) (_ *[]ServiceSSHPublicKey, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceSSHPublicKeys")
+	defer span.end(&err)
+
 	var empty []ServiceSSHPublicKey
 
 	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
@@ -943,18 +1137,16 @@ func (h *ServiceHandler) GetServiceSSHPublicKeys(service *Service) (*[]ServiceSS
 	}
 
 	req := struct {
-		JWT       string `json:"jwt"`
 		ServiceID string `json:"vmID"`
 		Action    string `json:"action"`
 	}{
-		JWT:       h.client.jwt,
 		ServiceID: service.ID,
 		Action:    "SSHPubKeysList",
 	}
 
-	bts, err := h.client.sendPostRequest(fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
+	bts, err := h.client.sendPostRequest(ctx, fmt.Sprintf("%s/api/servers/DoActionOnServer", h.client.BaseURL), req)
 	if err != nil {
-		return &empty, nil
+		return strictOrEmpty(ctx, h.client, &empty, err)
 	}
 
 	res := struct {
@@ -963,54 +1155,148 @@ func (h *ServiceHandler) GetServiceSSHPublicKeys(service *Service) (*[]ServiceSS
 	}{}
 
 	if err := checkAPIResponse(bts, &res); err != nil {
-		return &empty, nil
+		return strictOrEmpty(ctx, h.client, &empty, err)
 	}
 
 	return &res.Data, nil
 }
 
-func (h *ServiceHandler) formatServiceForClient(service *Service) (*Service, error) {
+// formatServicesForClient formats services concurrently with at most
+// opts.concurrency workers, the result keeps the order of services.
+// Failures of individual services are joined in the returned error.
+func (h *ServiceHandler) formatServicesForClient(ctx context.Context, services []Service, opts getOptions) ([]*Service, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	formatted := make([]*Service, len(services))
+	errs := make([]error, len(services))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(opts.concurrency, len(services)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				s, err := h.formatServiceForClient(ctx, &services[i], opts)
+				if err != nil {
+					errs[i] = fmt.Errorf("service %s: %w", services[i].ID, err)
+					continue
+				}
+				formatted[i] = s
+			}
+		}()
+	}
+
+	for i := range services {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return formatted, nil
+}
+
+func (h *ServiceHandler) formatServiceForClient(ctx context.Context, service *Service, opts getOptions) (*Service, error) {
 	if service == nil {
 		return nil, fmt.Errorf("cannot format nil service")
 	}
 
 	service.AdminUser = strings.Replace(service.AdminUser, "[EMAIL]", service.AdminEmail, -1)
 
-	env, err := h.GetServiceEnv(service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service env: %s", err)
+	// In strict mode the first failure is returned, otherwise failures
+	// are collected in service.PartialError.
+	strict := opts.strict || h.client.isStrict(ctx)
+	partial := &PartialError{ServiceID: service.ID}
+	failed := func(enrichment ServiceEnrichment, err error) error {
+		if strict {
+			return fmt.Errorf("failed to get service %s: %w", enrichment, err)
+		}
+		partial.Failures = append(partial.Failures, EnrichmentFailure{Enrichment: enrichment, Err: err})
+		return nil
+	}
+
+	// Getters only report their errors in strict mode
+	ctx = StrictContext(ctx)
+
+	if opts.enrichment.has(ServiceEnrichmentEnv) {
+		if env, err := h.GetServiceEnvCtx(ctx, service); err != nil {
+			// The variables of the lines that parsed are still returned
+			var syntaxErr *DotenvSyntaxError
+			if env != nil && errors.As(err, &syntaxErr) {
+				service.Env = *env
+			}
+			if err := failed(ServiceEnrichmentEnv, err); err != nil {
+				return nil, err
+			}
+		} else {
+			service.Env = *env
+		}
 	}
-	service.Env = *env
 
-	admin, err := h.GetServiceAdmin(service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service admin: %s", err)
+	if opts.enrichment.has(ServiceEnrichmentAdmin) {
+		if admin, err := h.GetServiceAdminCtx(ctx, service); err != nil {
+			if err := failed(ServiceEnrichmentAdmin, err); err != nil {
+				return nil, err
+			}
+		} else {
+			service.Admin = *admin
+		}
 	}
-	service.Admin = *admin
 
-	databaseAdmin, err := h.GetServiceDatabaseAdmin(service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service database admin: %s", err)
+	if opts.enrichment.has(ServiceEnrichmentDatabaseAdmin) {
+		if databaseAdmin, err := h.GetServiceDatabaseAdminCtx(ctx, service); err != nil {
+			if err := failed(ServiceEnrichmentDatabaseAdmin, err); err != nil {
+				return nil, err
+			}
+		} else {
+			service.DatabaseAdmin = *databaseAdmin
+		}
 	}
-	service.DatabaseAdmin = *databaseAdmin
 
-	firewallRules, err := h.GetServiceFirewallRules(service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service firewall rules: %s", err)
+	if opts.enrichment.has(ServiceEnrichmentFirewallRules) {
+		if firewallRules, err := h.GetServiceFirewallRulesCtx(ctx, service); err != nil {
+			if err := failed(ServiceEnrichmentFirewallRules, err); err != nil {
+				return nil, err
+			}
+		} else {
+			service.FirewallRules = *firewallRules
+		}
 	}
-	service.FirewallRules = *firewallRules
 
-	customDomainNames, err := h.GetServiceCustomDomainNames(service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service custom domain names: %s", err)
+	if opts.enrichment.has(ServiceEnrichmentCustomDomainNames) {
+		if customDomainNames, err := h.GetServiceCustomDomainNamesCtx(ctx, service); err != nil {
+			if err := failed(ServiceEnrichmentCustomDomainNames, err); err != nil {
+				return nil, err
+			}
+		} else {
+			service.CustomDomainNames = *customDomainNames
+		}
 	}
-	service.CustomDomainNames = *customDomainNames
 
-	sshPublicKeys, err := h.GetServiceSSHPublicKeys(service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service ssh public keys: %s", err)
+	if opts.enrichment.has(ServiceEnrichmentSSHPublicKeys) {
+		if sshPublicKeys, err := h.GetServiceSSHPublicKeysCtx(ctx, service); err != nil {
+			if err := failed(ServiceEnrichmentSSHPublicKeys, err); err != nil {
+				return nil, err
+			}
+		} else {
+			service.SSHPublicKeys = *sshPublicKeys
+		}
+	}
+
+	// A cancelled context is not a partial result
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(partial.Failures) > 0 {
+		service.PartialError = partial
 	}
-	service.SSHPublicKeys = *sshPublicKeys
 
 	return service, nil
 }
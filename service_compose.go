@@ -0,0 +1,136 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceCompose is the docker-compose definition of a service.
+type ServiceCompose struct {
+	// Raw is the content of the docker-compose.yml file.
+	Raw string
+	// Compose is the parsed content of Raw, nil if Raw is not valid.
+	Compose *Compose
+	// ValidationErr is the error returned by ParseCompose for Raw, if any.
+	ValidationErr error
+}
+
+// Compose is the subset of the docker-compose format the client knows of,
+// fields with several possible syntaxes are kept as decoded by yaml.v3.
+// Edit ServiceCompose.Raw to keep the fields that are not listed here.
+type Compose struct {
+	Version  string                    `yaml:"version,omitempty"`
+	Services map[string]ComposeService `yaml:"services"`
+	Volumes  map[string]any            `yaml:"volumes,omitempty"`
+	Networks map[string]any            `yaml:"networks,omitempty"`
+}
+
+type ComposeService struct {
+	Image         string `yaml:"image,omitempty"`
+	Build         any    `yaml:"build,omitempty"`
+	ContainerName string `yaml:"container_name,omitempty"`
+	Restart       string `yaml:"restart,omitempty"`
+	Command       any    `yaml:"command,omitempty"`
+	Entrypoint    any    `yaml:"entrypoint,omitempty"`
+	Environment   any    `yaml:"environment,omitempty"`
+	EnvFile       any    `yaml:"env_file,omitempty"`
+	Ports         []any  `yaml:"ports,omitempty"`
+	Volumes       []any  `yaml:"volumes,omitempty"`
+	DependsOn     any    `yaml:"depends_on,omitempty"`
+	Networks      any    `yaml:"networks,omitempty"`
+	Labels        any    `yaml:"labels,omitempty"`
+}
+
+// ParseCompose parses and validates a docker-compose definition: it must
+// define at least one service and every service needs an image or a build.
+func ParseCompose(raw string) (*Compose, error) {
+	var compose Compose
+	if err := yaml.Unmarshal([]byte(raw), &compose); err != nil {
+		return nil, fmt.Errorf("invalid compose file: %w", err)
+	}
+
+	if len(compose.Services) == 0 {
+		return nil, errors.New("invalid compose file: no services defined")
+	}
+
+	names := make([]string, 0, len(compose.Services))
+	for name := range compose.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		s := compose.Services[name]
+		if s.Image == "" && s.Build == nil {
+			errs = append(errs, fmt.Errorf("service %s: image or build is required", name))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid compose file: %w", err)
+	}
+
+	return &compose, nil
+}
+
+// GetServiceCompose returns the docker-compose definition of a deployed service.
+// Raw is returned even if it does not pass ParseCompose, the reason is then
+// reported in ServiceCompose.ValidationErr.
+func (h *ServiceHandler) GetServiceCompose(service *Service) (*ServiceCompose, error) {
+	return h.GetServiceComposeCtx(context.Background(), service)
+}
+
+// GetServiceComposeCtx is like GetServiceCompose but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) GetServiceComposeCtx(ctx context.Context, service *Service) (_ *ServiceCompose, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.GetServiceCompose")
+	defer span.end(&err)
+
+	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
+		return nil, fmt.Errorf("service %s is not deployed", service.ID)
+	}
+
+	config, err := h.getAppStackConfig(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	compose, validationErr := ParseCompose(config.Compose)
+
+	return &ServiceCompose{Raw: config.Compose, Compose: compose, ValidationErr: validationErr}, nil
+}
+
+// UpdateServiceCompose replaces the docker-compose definition of a deployed
+// service with raw, once validated with ParseCompose. The .env file is kept.
+func (h *ServiceHandler) UpdateServiceCompose(service *Service, raw string, opts ...UpdateOption) error {
+	return h.UpdateServiceComposeCtx(context.Background(), service, raw, opts...)
+}
+
+// UpdateServiceComposeCtx is like UpdateServiceCompose but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) UpdateServiceComposeCtx(ctx context.Context, service *Service, raw string, opts ...UpdateOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.UpdateServiceCompose")
+	defer span.end(&err)
+
+	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
+		return fmt.Errorf("service %s is not deployed", service.ID)
+	}
+
+	if _, err = ParseCompose(raw); err != nil {
+		return err
+	}
+
+	config, err := h.getAppStackConfig(ctx, service)
+	if err != nil {
+		return fmt.Errorf("failed to get service compose: %w", err)
+	}
+
+	config.Compose = raw
+	if err = h.updateAppStackConfig(ctx, service, *config); err != nil {
+		return err
+	}
+
+	return h.restartIfRequested(ctx, service, newUpdateOptions(opts))
+}
@@ -0,0 +1,98 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCompose = `services:
+  app:
+    image: nginx:latest
+    restart: always
+    ports:
+      - 80
+      - "172.17.0.1:8080:80"
+    environment:
+      - FOO=bar
+  db:
+    image: postgres:16
+`
+
+func TestParseCompose(t *testing.T) {
+	compose, err := ParseCompose(testCompose)
+	require.NoError(t, err)
+	require.Len(t, compose.Services, 2)
+	require.Equal(t, "nginx:latest", compose.Services["app"].Image)
+	require.Equal(t, []any{80, "172.17.0.1:8080:80"}, compose.Services["app"].Ports)
+}
+
+func TestParseCompose_Invalid(t *testing.T) {
+	_, err := ParseCompose("services: [")
+	require.ErrorContains(t, err, "invalid compose file")
+
+	_, err = ParseCompose("version: '3'\n")
+	require.EqualError(t, err, "invalid compose file: no services defined")
+
+	_, err = ParseCompose("services:\n  b:\n    restart: always\n  a:\n    ports: [80]\n")
+	require.EqualError(t, err, "invalid compose file: service a: image or build is required\nservice b: image or build is required")
+}
+
+func TestServiceHandler_UpdateServiceCompose(t *testing.T) {
+	var written struct {
+		Env     string `json:"envData"`
+		Compose string `json:"composeData"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Action string `json:"action"`
+		}
+		bts, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bts, &req))
+
+		switch req.Action {
+		case "getAppStackConfig":
+			_, _ = fmt.Fprintf(w, `{"status":"OK","data":{"envResult":"A=1\n","composeResult":%q}}`, "services:\n  app:\n    image: old\n")
+		case "updateAppStackConfig":
+			require.NoError(t, json.Unmarshal(bts, &written))
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+	service := &Service{ID: "1", ProjectID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed}
+
+	current, err := c.Service.GetServiceCompose(service)
+	require.NoError(t, err)
+	require.Equal(t, "old", current.Compose.Services["app"].Image)
+	require.NoError(t, current.ValidationErr)
+
+	err = c.Service.UpdateServiceCompose(service, "services:\n  app:\n")
+	require.ErrorContains(t, err, "image or build is required")
+	require.Empty(t, written.Compose)
+
+	require.NoError(t, c.Service.UpdateServiceCompose(service, testCompose))
+	require.Equal(t, testCompose, written.Compose)
+	require.Equal(t, "A=1\n", written.Env)
+}
+
+func TestServiceHandler_GetServiceCompose_Invalid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status":"OK","data":{"envResult":"","composeResult":"services:\n  app:\n"}}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+	service := &Service{ID: "1", ProjectID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed}
+
+	current, err := c.Service.GetServiceCompose(service)
+	require.NoError(t, err)
+	require.Equal(t, "services:\n  app:\n", current.Raw)
+	require.Nil(t, current.Compose)
+	require.ErrorContains(t, current.ValidationErr, "image or build is required")
+}
@@ -0,0 +1,151 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// UpdateOption configures the methods of ServiceHandler that update the
+// application configuration of a service.
+type UpdateOption func(*updateOptions)
+
+type updateOptions struct {
+	restart bool
+}
+
+func newUpdateOptions(opts []UpdateOption) updateOptions {
+	var o updateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithRestart restarts the application stack with
+// ServiceHandler.RestartAppStack once the configuration is updated.
+func WithRestart() UpdateOption {
+	return func(o *updateOptions) {
+		o.restart = true
+	}
+}
+
+// SetServiceEnv replaces the environment variables of a deployed service
+// with env. Comments and the position of the variables that are kept are
+// preserved, new variables are appended in alphabetical order.
+func (h *ServiceHandler) SetServiceEnv(service *Service, env map[string]string, opts ...UpdateOption) error {
+	return h.SetServiceEnvCtx(context.Background(), service, env, opts...)
+}
+
+// SetServiceEnvCtx is like SetServiceEnv but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) SetServiceEnvCtx(ctx context.Context, service *Service, env map[string]string, opts ...UpdateOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.SetServiceEnv")
+	defer span.end(&err)
+
+	if err = validateEnvKeys(env); err != nil {
+		return err
+	}
+
+	return h.updateServiceEnv(ctx, service, opts, func(d *Dotenv) error {
+		for _, key := range d.Keys() {
+			if _, ok := env[key]; !ok {
+				d.Unset(key)
+			}
+		}
+		return setSorted(d, env)
+	})
+}
+
+// PatchServiceEnv sets and unsets some environment variables of a deployed
+// service, leaving the other ones untouched.
+func (h *ServiceHandler) PatchServiceEnv(service *Service, set map[string]string, unset []string, opts ...UpdateOption) error {
+	return h.PatchServiceEnvCtx(context.Background(), service, set, unset, opts...)
+}
+
+// PatchServiceEnvCtx is like PatchServiceEnv but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) PatchServiceEnvCtx(ctx context.Context, service *Service, set map[string]string, unset []string, opts ...UpdateOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.PatchServiceEnv")
+	defer span.end(&err)
+
+	if err = validateEnvKeys(set); err != nil {
+		return err
+	}
+
+	return h.updateServiceEnv(ctx, service, opts, func(d *Dotenv) error {
+		for _, key := range unset {
+			d.Unset(key)
+		}
+		return setSorted(d, set)
+	})
+}
+
+// validateEnvKeys checks the variable names of env before any request is sent.
+func validateEnvKeys(env map[string]string) error {
+	var errs []error
+	for key := range env {
+		if err := validateDotenvKey(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setSorted sets the variables of env in alphabetical order, so that new
+// ones are appended in a stable order.
+func setSorted(d *Dotenv, env map[string]string) error {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if err := d.Set(key, env[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// updateServiceEnv fetches the .env file of the service, applies update and
+// writes it back. Nothing is written if update returns an error.
+func (h *ServiceHandler) updateServiceEnv(ctx context.Context, service *Service, opts []UpdateOption, update func(*Dotenv) error) error {
+	if service.DeploymentStatus != ServiceDeploymentStatusDeployed {
+		return fmt.Errorf("service %s is not deployed", service.ID)
+	}
+
+	config, err := h.getAppStackConfig(ctx, service)
+	if err != nil {
+		return fmt.Errorf("failed to get service env: %w", err)
+	}
+
+	dotenv, err := ParseDotenv(config.Env)
+	if err != nil {
+		return fmt.Errorf("failed to parse service env: %w", err)
+	}
+
+	if err = update(dotenv); err != nil {
+		return err
+	}
+
+	config.Env = dotenv.String()
+	if err = h.updateAppStackConfig(ctx, service, *config); err != nil {
+		return err
+	}
+
+	return h.restartIfRequested(ctx, service, newUpdateOptions(opts))
+}
+
+// restartIfRequested restarts the application stack when WithRestart is set.
+func (h *ServiceHandler) restartIfRequested(ctx context.Context, service *Service, o updateOptions) error {
+	if !o.restart {
+		return nil
+	}
+
+	return h.RestartAppStackCtx(ctx, service.ID)
+}
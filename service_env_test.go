@@ -0,0 +1,100 @@
+package elestio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceHandler_PatchServiceEnv(t *testing.T) {
+	var actions []string
+	var written, writtenCompose string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Action  string `json:"action"`
+			Env     string `json:"envData"`
+			Compose string `json:"composeData"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		actions = append(actions, req.Action)
+
+		switch req.Action {
+		case "getAppStackConfig":
+			_, _ = fmt.Fprint(w, `{"status":"OK","data":{"envResult":"# app\nA=1\nB=x=y\n","composeResult":"services: {}\n"}}`)
+		case "updateAppStackConfig":
+			written, writtenCompose = req.Env, req.Compose
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	service := &Service{ID: "1", ProjectID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed}
+	err := c.Service.PatchServiceEnv(service, map[string]string{"C": "3", "A": "2"}, []string{"B"}, WithRestart())
+	require.NoError(t, err)
+	require.Equal(t, "# app\nA=2\nC=3\n", written)
+	require.Equal(t, "services: {}\n", writtenCompose, "expected the compose file to be sent unchanged")
+	require.Equal(t, []string{"getAppStackConfig", "updateAppStackConfig", "restartAppStack"}, actions)
+
+	env, err := c.Service.GetServiceEnv(service)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"A": "1", "B": "x=y"}, *env)
+}
+
+func TestServiceHandler_SetServiceEnv_NotDeployed(t *testing.T) {
+	c := NewUnsignedClient()
+
+	err := c.Service.SetServiceEnv(&Service{ID: "1"}, map[string]string{"A": "1"})
+	require.EqualError(t, err, "service 1 is not deployed")
+}
+
+func TestServiceHandler_SetServiceEnv_MissingCompose(t *testing.T) {
+	var actions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Action string `json:"action"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		actions = append(actions, req.Action)
+		_, _ = fmt.Fprint(w, `{"status":"OK","data":{"envResult":"A=1\n"}}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	service := &Service{ID: "1", ProjectID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed}
+	err := c.Service.SetServiceEnv(service, map[string]string{"A": "2"})
+	require.EqualError(t, err, "refusing to update service 1 without its docker-compose file")
+	require.Equal(t, []string{"getAppStackConfig"}, actions)
+}
+
+func TestServiceHandler_GetServiceEnv_SyntaxError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status":"OK","data":{"envResult":"A=1\nB=\"open\nC=3\n"}}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	service := &Service{ID: "1", ProjectID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed}
+	env, err := c.Service.GetServiceEnv(service)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"A": "1", "C": "3"}, *env)
+
+	env, err = c.Service.GetServiceEnvCtx(StrictContext(context.Background()), service)
+	require.EqualError(t, err, "failed to parse service env: line 2: unterminated quoted value for B")
+	require.Equal(t, map[string]string{"A": "1", "C": "3"}, *env)
+}
+
+func TestServiceHandler_PatchServiceEnv_InvalidKey(t *testing.T) {
+	c := NewUnsignedClient(WithBaseURL("http://127.0.0.1:0"))
+
+	service := &Service{ID: "1", ProjectID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed}
+	err := c.Service.PatchServiceEnv(service, map[string]string{"A=B": "1"}, nil)
+	require.EqualError(t, err, "invalid variable name 'A=B': contains '='")
+}
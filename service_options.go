@@ -0,0 +1,166 @@
+package elestio
+
+import (
+	"context"
+	"strings"
+)
+
+// ServiceEnrichment is a set of sub-resources that ServiceHandler.Get and
+// ServiceHandler.GetList fetch on top of the service details, each one
+// costs an extra request per service.
+type ServiceEnrichment uint
+
+const (
+	ServiceEnrichmentEnv ServiceEnrichment = 1 << iota
+	ServiceEnrichmentAdmin
+	ServiceEnrichmentDatabaseAdmin
+	ServiceEnrichmentFirewallRules
+	ServiceEnrichmentCustomDomainNames
+	ServiceEnrichmentSSHPublicKeys
+
+	ServiceEnrichmentNone ServiceEnrichment = 0
+	ServiceEnrichmentAll                    = ServiceEnrichmentEnv |
+		ServiceEnrichmentAdmin |
+		ServiceEnrichmentDatabaseAdmin |
+		ServiceEnrichmentFirewallRules |
+		ServiceEnrichmentCustomDomainNames |
+		ServiceEnrichmentSSHPublicKeys
+)
+
+// GetOption configures ServiceHandler.Get and ServiceHandler.GetList.
+//
+// Without options every sub-resource is fetched. As soon as an enrichment
+// option is given, only the selected sub-resources are fetched.
+type GetOption func(*getOptions)
+
+// defaultEnrichmentConcurrency is the number of services
+// ServiceHandler.GetList enriches concurrently by default.
+const defaultEnrichmentConcurrency = 4
+
+type getOptions struct {
+	enrichment  ServiceEnrichment
+	selected    bool
+	concurrency int
+	strict      bool
+}
+
+func newGetOptions(opts []GetOption) getOptions {
+	o := getOptions{enrichment: ServiceEnrichmentAll, concurrency: defaultEnrichmentConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithEnrichment selects the given sub-resources.
+func WithEnrichment(enrichment ServiceEnrichment) GetOption {
+	return func(o *getOptions) {
+		if !o.selected {
+			o.enrichment, o.selected = ServiceEnrichmentNone, true
+		}
+		o.enrichment |= enrichment
+	}
+}
+
+// WithoutEnrichment only returns the service details, in a single request.
+func WithoutEnrichment() GetOption {
+	return WithEnrichment(ServiceEnrichmentNone)
+}
+
+// WithEnv selects Service.Env.
+func WithEnv() GetOption {
+	return WithEnrichment(ServiceEnrichmentEnv)
+}
+
+// WithCredentials selects Service.Admin and Service.DatabaseAdmin.
+func WithCredentials() GetOption {
+	return WithEnrichment(ServiceEnrichmentAdmin | ServiceEnrichmentDatabaseAdmin)
+}
+
+// WithFirewall selects Service.FirewallRules.
+func WithFirewall() GetOption {
+	return WithEnrichment(ServiceEnrichmentFirewallRules)
+}
+
+// WithCustomDomainNames selects Service.CustomDomainNames.
+func WithCustomDomainNames() GetOption {
+	return WithEnrichment(ServiceEnrichmentCustomDomainNames)
+}
+
+// WithSSHPublicKeys selects Service.SSHPublicKeys.
+func WithSSHPublicKeys() GetOption {
+	return WithEnrichment(ServiceEnrichmentSSHPublicKeys)
+}
+
+// WithConcurrency sets how many services ServiceHandler.GetList enriches
+// concurrently. Requests still go through the client rate limit if any.
+func WithConcurrency(n int) GetOption {
+	return func(o *getOptions) {
+		o.concurrency = max(n, 1)
+	}
+}
+
+// WithStrict makes ServiceHandler.Get and ServiceHandler.GetList fail when
+// a sub-resource cannot be fetched, instead of setting Service.PartialError.
+func WithStrict() GetOption {
+	return func(o *getOptions) {
+		o.strict = true
+	}
+}
+
+// String returns the name of the sub-resources in the set.
+func (s ServiceEnrichment) String() string {
+	names := []string{}
+	for _, e := range []struct {
+		enrichment ServiceEnrichment
+		name       string
+	}{
+		{ServiceEnrichmentEnv, "env"},
+		{ServiceEnrichmentAdmin, "admin"},
+		{ServiceEnrichmentDatabaseAdmin, "database admin"},
+		{ServiceEnrichmentFirewallRules, "firewall rules"},
+		{ServiceEnrichmentCustomDomainNames, "custom domain names"},
+		{ServiceEnrichmentSSHPublicKeys, "ssh public keys"},
+	} {
+		if s.has(e.enrichment) {
+			names = append(names, e.name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "none"
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// has reports whether the enrichment set contains all of e.
+func (s ServiceEnrichment) has(e ServiceEnrichment) bool {
+	return s&e == e
+}
+
+type strictContextKey struct{}
+
+// StrictContext returns a copy of ctx in which the ServiceHandler getters
+// (GetServiceEnvCtx, GetServiceAdminCtx, ...) return request and decoding
+// errors instead of an empty value, see also WithStrictMode.
+func StrictContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, strictContextKey{}, true)
+}
+
+// isStrict reports whether sub-resource errors must be returned for ctx.
+func (c *Client) isStrict(ctx context.Context) bool {
+	strict, _ := ctx.Value(strictContextKey{}).(bool)
+	return strict || c.strict
+}
+
+// strictOrEmpty returns empty and a nil error, or err in strict mode.
+func strictOrEmpty[T any](ctx context.Context, c *Client, empty T, err error) (T, error) {
+	if c.isStrict(ctx) {
+		var zero T
+		return zero, err
+	}
+
+	return empty, nil
+}
@@ -0,0 +1,171 @@
+package elestio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupServiceServer serves a deployed service with an enabled firewall and
+// records the endpoints and actions it receives.
+func setupServiceServer(t *testing.T) (*Client, func() []string) {
+	var mu sync.Mutex
+	var calls []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Action string `json:"action"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		calls = append(calls, r.URL.Path+" "+body.Action)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/servers/getServerDetails":
+			_, _ = w.Write([]byte(`{"status":"OK","serviceInfos":[{"vmID":"1","deploymentStatus":"Deployed","isFirewallActivated":1}]}`))
+		case "/api/servers/getServices":
+			_, _ = w.Write([]byte(`{"status":"OK","servers":[{"vmID":"1","deploymentStatus":"Deployed"},{"vmID":"2","deploymentStatus":"Deployed"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"OK","rules":[{"type":"INPUT","port":"22","protocol":"tcp","targets":["0.0.0.0/0"]}]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewUnsignedClient(WithBaseURL(srv.URL)), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestServiceHandler_Get_WithoutEnrichment(t *testing.T) {
+	c, calls := setupServiceServer(t)
+
+	service, err := c.Service.Get("1", "1", WithoutEnrichment())
+	require.NoError(t, err)
+	require.Equal(t, "1", service.ID)
+	require.Equal(t, []string{"/api/servers/getServerDetails "}, calls(), "expected a single request")
+}
+
+func TestServiceHandler_Get_WithFirewall(t *testing.T) {
+	c, calls := setupServiceServer(t)
+
+	service, err := c.Service.Get("1", "1", WithFirewall())
+	require.NoError(t, err)
+	require.Len(t, service.FirewallRules, 1)
+	require.Equal(t, []string{
+		"/api/servers/getServerDetails ",
+		"/api/servers/DoActionOnServer getFirewallRules",
+	}, calls())
+}
+
+func TestNewGetOptions(t *testing.T) {
+	require.Equal(t, ServiceEnrichmentAll, newGetOptions(nil).enrichment, "expected all sub-resources by default")
+	require.Equal(t, ServiceEnrichmentNone, newGetOptions([]GetOption{WithoutEnrichment()}).enrichment)
+	require.Equal(t,
+		ServiceEnrichmentEnv|ServiceEnrichmentAdmin|ServiceEnrichmentDatabaseAdmin,
+		newGetOptions([]GetOption{WithEnv(), WithCredentials()}).enrichment,
+	)
+}
+
+func TestServiceHandler_GetList_KeepsOrder(t *testing.T) {
+	c, calls := setupServiceServer(t)
+
+	services, err := c.Service.GetList("1", WithSSHPublicKeys(), WithConcurrency(2))
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	require.Equal(t, "1", services[0].ID)
+	require.Equal(t, "2", services[1].ID)
+	require.Len(t, calls(), 3, "expected one list request and one enrichment request per service")
+}
+
+func setupFailingFirewallServer(t *testing.T, opts ...Option) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Action string `json:"action"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch {
+		case r.URL.Path == "/api/servers/getServerDetails":
+			_, _ = w.Write([]byte(`{"status":"OK","serviceInfos":[{"vmID":"1","deploymentStatus":"Deployed","isFirewallActivated":1}]}`))
+		case r.URL.Path == "/api/servers/getServices":
+			_, _ = w.Write([]byte(`{"status":"OK","servers":[{"vmID":"1","deploymentStatus":"Deployed","isFirewallActivated":1},{"vmID":"2","deploymentStatus":"Deployed","isFirewallActivated":1}]}`))
+		case body.Action == "getFirewallRules":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"status":"OK"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewUnsignedClient(append([]Option{WithBaseURL(srv.URL), WithRetryPolicy(&RetryPolicy{MaxAttempts: 1})}, opts...)...)
+}
+
+func TestServiceHandler_Get_PartialError(t *testing.T) {
+	c := setupFailingFirewallServer(t)
+
+	service, err := c.Service.Get("1", "1", WithFirewall(), WithSSHPublicKeys())
+	require.NoError(t, err, "expected no error outside of strict mode")
+	require.NotNil(t, service.PartialError, "expected a partial error")
+	require.True(t, service.PartialError.Failed(ServiceEnrichmentFirewallRules))
+	require.False(t, service.PartialError.Failed(ServiceEnrichmentSSHPublicKeys))
+
+	var apiErr *APIError
+	require.ErrorAs(t, service.PartialError, &apiErr)
+	require.Equal(t, http.StatusServiceUnavailable, apiErr.HTTPStatusCode)
+}
+
+func TestServiceHandler_Get_Strict(t *testing.T) {
+	c := setupFailingFirewallServer(t)
+
+	_, err := c.Service.Get("1", "1", WithStrict())
+	require.ErrorContains(t, err, "failed to get service firewall rules")
+
+	_, err = c.Service.GetServiceFirewallRulesCtx(StrictContext(context.Background()), &Service{ID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed, FirewallEnabled: 1})
+	require.Error(t, err, "expected getter to return the error with a strict context")
+
+	rules, err := c.Service.GetServiceFirewallRules(&Service{ID: "1", DeploymentStatus: ServiceDeploymentStatusDeployed, FirewallEnabled: 1})
+	require.NoError(t, err, "expected getter to swallow the error by default")
+	require.Empty(t, *rules)
+}
+
+func TestServiceHandler_GetList_StrictModeJoinsErrors(t *testing.T) {
+	c := setupFailingFirewallServer(t, WithStrictMode())
+
+	_, err := c.Service.GetList("1", WithFirewall())
+	require.ErrorContains(t, err, "service 1: failed to get service firewall rules")
+	require.ErrorContains(t, err, "service 2: failed to get service firewall rules")
+}
+
+func TestServiceHandler_Get_MalformedEnv(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/servers/getServerDetails":
+			_, _ = w.Write([]byte(`{"status":"OK","serviceInfos":[{"vmID":"1","deploymentStatus":"Deployed"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"envResult":"A=1\nB=\"open\nC=3\n","composeResult":"services: {}\n"}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	service, err := c.Service.Get("1", "1", WithEnv())
+	require.NoError(t, err, "expected no error outside of strict mode")
+	require.Equal(t, map[string]string{"A": "1", "C": "3"}, service.Env, "expected the variables that parsed")
+	require.True(t, service.PartialError.Failed(ServiceEnrichmentEnv))
+
+	var syntaxErr *DotenvSyntaxError
+	require.ErrorAs(t, service.PartialError, &syntaxErr)
+	require.Equal(t, 2, syntaxErr.Line)
+
+	_, err = c.Service.Get("1", "1", WithEnv(), WithStrict())
+	require.ErrorAs(t, err, &syntaxErr, "expected the syntax error in strict mode")
+}
@@ -0,0 +1,112 @@
+package elestio
+
+import (
+	"context"
+	"fmt"
+)
+
+// PowerOption configures the power lifecycle methods of ServiceHandler.
+type PowerOption func(*powerOptions)
+
+type powerOptions struct {
+	wait      bool
+	projectID string
+	waitOpts  []WaitOption
+}
+
+// WithWait makes PowerOn, Shutdown and PowerOff wait, with
+// ServiceHandler.WaitUntilStatus, for the service to reach its target status.
+func WithWait(projectID string, opts ...WaitOption) PowerOption {
+	return func(o *powerOptions) {
+		o.wait = true
+		o.projectID = projectID
+		o.waitOpts = opts
+	}
+}
+
+// PowerOn starts a stopped service.
+func (h *ServiceHandler) PowerOn(serviceId string, opts ...PowerOption) error {
+	return h.PowerOnCtx(context.Background(), serviceId, opts...)
+}
+
+// PowerOnCtx is like PowerOn but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) PowerOnCtx(ctx context.Context, serviceId string, opts ...PowerOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.PowerOn")
+	defer span.end(&err)
+
+	return h.doPowerAction(ctx, serviceId, "poweron", ServiceStatusRunning, opts)
+}
+
+// Shutdown gracefully shuts the service down.
+func (h *ServiceHandler) Shutdown(serviceId string, opts ...PowerOption) error {
+	return h.ShutdownCtx(context.Background(), serviceId, opts...)
+}
+
+// ShutdownCtx is like Shutdown but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) ShutdownCtx(ctx context.Context, serviceId string, opts ...PowerOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.Shutdown")
+	defer span.end(&err)
+
+	return h.doPowerAction(ctx, serviceId, "shutdown", ServiceStatusStopped, opts)
+}
+
+// PowerOff cuts the power of the service, prefer Shutdown when possible.
+func (h *ServiceHandler) PowerOff(serviceId string, opts ...PowerOption) error {
+	return h.PowerOffCtx(context.Background(), serviceId, opts...)
+}
+
+// PowerOffCtx is like PowerOff but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) PowerOffCtx(ctx context.Context, serviceId string, opts ...PowerOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.PowerOff")
+	defer span.end(&err)
+
+	return h.doPowerAction(ctx, serviceId, "poweroff", ServiceStatusStopped, opts)
+}
+
+// Reset hard resets the service, prefer RebootServer when possible.
+// The service is usually still reported as running during a reset, so
+// there is no status to wait for.
+func (h *ServiceHandler) Reset(serviceId string) error {
+	return h.ResetCtx(context.Background(), serviceId)
+}
+
+// ResetCtx is like Reset but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) ResetCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.Reset")
+	defer span.end(&err)
+
+	return h.doPowerAction(ctx, serviceId, "reset", "", nil)
+}
+
+// RestartAppStack restarts the application containers without rebooting
+// the service. The service stays running, so there is no status to wait for.
+func (h *ServiceHandler) RestartAppStack(serviceId string) error {
+	return h.RestartAppStackCtx(context.Background(), serviceId)
+}
+
+// RestartAppStackCtx is like RestartAppStack but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) RestartAppStackCtx(ctx context.Context, serviceId string) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.RestartAppStack")
+	defer span.end(&err)
+
+	return h.doPowerAction(ctx, serviceId, "restartAppStack", "", nil)
+}
+
+// doPowerAction runs action on the service and waits for status if requested.
+func (h *ServiceHandler) doPowerAction(ctx context.Context, serviceId, action, status string, opts []PowerOption) error {
+	var o powerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := h.DoActionOnServerCtx(ctx, serviceId, action); err != nil {
+		return fmt.Errorf("failed to %s service %s: %w", action, serviceId, err)
+	}
+
+	if !o.wait {
+		return nil
+	}
+
+	_, err := h.WaitUntilStatusCtx(ctx, o.projectID, serviceId, status, o.waitOpts...)
+	return err
+}
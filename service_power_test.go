@@ -0,0 +1,68 @@
+package elestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceHandler_Shutdown_WithWait(t *testing.T) {
+	var action string
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/servers/DoActionOnServer":
+			var req struct {
+				Action string `json:"action"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			action = req.Action
+			_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+		case "/api/servers/getServerDetails":
+			status := ServiceStatusRunning
+			if polls.Add(1) > 1 {
+				status = ServiceStatusStopped
+			}
+			_, _ = fmt.Fprintf(w, `{"status":"OK","serviceInfos":[{"vmID":"1","status":%q}]}`, status)
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	err := c.Service.Shutdown("1", WithWait("1", WithPollInterval(time.Millisecond)))
+	require.NoError(t, err)
+	require.Equal(t, "shutdown", action)
+	require.Equal(t, int32(2), polls.Load())
+}
+
+func TestServiceHandler_PowerOn_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status":"KO","message":"server is locked"}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	err := c.Service.PowerOn("1")
+	require.ErrorContains(t, err, "failed to poweron service 1")
+	require.ErrorContains(t, err, "server is locked")
+}
+
+func TestServiceHandler_RestartAppStack(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = fmt.Fprint(w, `{"status":"OK"}`)
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	require.NoError(t, c.Service.RestartAppStack("1"))
+	require.NoError(t, c.Service.Reset("1"))
+	require.Equal(t, []string{"/api/servers/DoActionOnServer", "/api/servers/DoActionOnServer"}, paths)
+}
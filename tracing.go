@@ -0,0 +1,83 @@
+package elestio
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/elestio/elestio-go-api-client/v2"
+
+// operationSpan is the span covering a handler method.
+type operationSpan struct {
+	trace.Span
+}
+
+// tracer returns the client tracer, spans are dropped when no
+// TracerProvider is configured.
+func (c *Client) tracer() trace.Tracer {
+	if c.TracerProvider == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+
+	return c.TracerProvider.Tracer(tracerName)
+}
+
+// startOperation marks ctx with the operation name and starts its span,
+// the span must be ended with end.
+func (c *Client) startOperation(ctx context.Context, name string) (context.Context, operationSpan) {
+	ctx = withOperation(ctx, name)
+	ctx, span := c.tracer().Start(ctx, name, trace.WithAttributes(
+		attribute.String("elestio.operation", OperationName(ctx)),
+	))
+
+	return ctx, operationSpan{span}
+}
+
+// end records the error pointed by errp, if any, and ends the span.
+func (s operationSpan) end(errp *error) {
+	if errp != nil && *errp != nil {
+		s.RecordError(*errp)
+		s.SetStatus(codes.Error, (*errp).Error())
+	}
+
+	s.End()
+}
+
+// startRequestSpan starts the span covering one HTTP attempt of an operation.
+func (c *Client) startRequestSpan(ctx context.Context, op Operation, method, endpoint string, attempt int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", endpoint),
+		attribute.Int("http.request.resend_count", attempt-1),
+		attribute.String("elestio.operation", op.Name),
+	}
+	if op.Action != "" {
+		attrs = append(attrs, attribute.String("elestio.action", op.Action))
+	}
+
+	return c.tracer().Start(ctx, fmt.Sprintf("%s %s", method, endpoint),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endRequestSpan records the response status code or the error of an HTTP attempt and ends its span.
+func endRequestSpan(span trace.Span, statusCode int, err error) {
+	if statusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if statusCode >= 400 {
+		span.SetStatus(codes.Error, fmt.Sprintf("status code %d", statusCode))
+	}
+
+	span.End()
+}
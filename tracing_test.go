@@ -0,0 +1,67 @@
+package elestio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_Spans(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/servers/getServerDetails":
+			_, _ = w.Write([]byte(`{"status":"OK","serviceInfos":[{"vmID":"1","deploymentStatus":"IN PROGRESS"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithTracerProvider(tp))
+
+	_, err := c.Service.Get("1", "1")
+	require.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	require.Contains(t, spans, "Service.Get", "expected a span for the operation")
+	require.Contains(t, spans, "POST /api/servers/getServerDetails", "expected a span for the HTTP request")
+	require.Equal(t,
+		spans["Service.Get"].SpanContext().SpanID(),
+		spans["POST /api/servers/getServerDetails"].Parent().SpanID(),
+		"expected HTTP span to be a child of the operation span",
+	)
+}
+
+func TestTracing_RecordsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK","serviceInfos":[]}`))
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c := NewUnsignedClient(WithBaseURL(srv.URL), WithTracerProvider(tp))
+
+	_, err := c.LoadBalancer.Get("1", "1")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for _, span := range recorder.Ended() {
+		if span.Name() == "LoadBalancer.Get" {
+			require.Equal(t, "load balancer not found", span.Status().Description)
+			return
+		}
+	}
+	t.Fatal("expected a span for LoadBalancer.Get")
+}
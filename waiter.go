@@ -0,0 +1,317 @@
+package elestio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// WaitOption configures the Wait* methods of the handlers.
+type WaitOption func(*waitOptions)
+
+// WaitProgress is reported to the WithProgress callback after every poll.
+type WaitProgress struct {
+	// Attempt is the number of polls so far, starting at 1.
+	Attempt int
+	// Elapsed is the time spent waiting so far.
+	Elapsed time.Duration
+	// Status is the last observed status, empty for load balancers.
+	Status string
+	// DeploymentStatus is the last observed deployment status.
+	DeploymentStatus string
+}
+
+type waitOptions struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	multiplier  float64
+	timeout     time.Duration
+	progress    func(WaitProgress)
+}
+
+func newWaitOptions(opts []WaitOption) waitOptions {
+	o := waitOptions{
+		interval:    10 * time.Second,
+		maxInterval: time.Minute,
+		multiplier:  1.5,
+		timeout:     30 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithPollInterval sets the delay between the first two polls, 10s by default.
+// A value lower than or equal to 0 keeps the default.
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WithMaxPollInterval caps the delay between two polls, 1m by default.
+func WithMaxPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.maxInterval = d
+	}
+}
+
+// WithBackoffMultiplier sets the factor applied to the poll interval after
+// every poll, 1.5 by default. Use 1 to poll at a fixed interval.
+func WithBackoffMultiplier(multiplier float64) WaitOption {
+	return func(o *waitOptions) {
+		o.multiplier = max(multiplier, 1)
+	}
+}
+
+// WithWaitTimeout sets how long to wait before giving up, 30m by default.
+// A value of 0 only relies on the context deadline.
+func WithWaitTimeout(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = d
+	}
+}
+
+// WithProgress sets a callback called after every poll.
+func WithProgress(fn func(WaitProgress)) WaitOption {
+	return func(o *waitOptions) {
+		o.progress = fn
+	}
+}
+
+// poll calls check until it reports done or fails, waiting between calls
+// according to the options. It gives up when ctx is done or on timeout.
+func poll(ctx context.Context, o waitOptions, check func(ctx context.Context) (done bool, progress WaitProgress, err error)) error {
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	interval := o.interval
+	for attempt := 1; ; attempt++ {
+		done, progress, err := check(ctx)
+		if err != nil {
+			// The deadline may expire in the middle of a poll
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+
+		if o.progress != nil {
+			progress.Attempt = attempt
+			progress.Elapsed = time.Since(start)
+			o.progress(progress)
+		}
+
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * o.multiplier)
+		if o.maxInterval > 0 && interval > o.maxInterval {
+			interval = o.maxInterval
+		}
+	}
+}
+
+// WaitUntilDeployed polls a service until its deployment status is
+// ServiceDeploymentStatusDeployed and returns it.
+// The returned service is fetched with WithoutEnrichment.
+func (h *ServiceHandler) WaitUntilDeployed(projectID, serviceID string, opts ...WaitOption) (*Service, error) {
+	return h.WaitUntilDeployedCtx(context.Background(), projectID, serviceID, opts...)
+}
+
+// WaitUntilDeployedCtx is like WaitUntilDeployed but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) WaitUntilDeployedCtx(ctx context.Context, projectID, serviceID string, opts ...WaitOption) (_ *Service, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.WaitUntilDeployed")
+	defer span.end(&err)
+
+	service, err := h.waitFor(ctx, projectID, serviceID, opts, func(s *Service) bool {
+		return s.DeploymentStatus == ServiceDeploymentStatusDeployed
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for service %s to be deployed: %w", serviceID, err)
+	}
+
+	return service, nil
+}
+
+// WaitUntilStatus polls a service until its status is the given one,
+// e.g. ServiceStatusRunning or ServiceStatusStopped, and returns it.
+// The returned service is fetched with WithoutEnrichment.
+func (h *ServiceHandler) WaitUntilStatus(projectID, serviceID, status string, opts ...WaitOption) (*Service, error) {
+	return h.WaitUntilStatusCtx(context.Background(), projectID, serviceID, status, opts...)
+}
+
+// WaitUntilStatusCtx is like WaitUntilStatus but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) WaitUntilStatusCtx(ctx context.Context, projectID, serviceID, status string, opts ...WaitOption) (_ *Service, err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.WaitUntilStatus")
+	defer span.end(&err)
+
+	service, err := h.waitFor(ctx, projectID, serviceID, opts, func(s *Service) bool {
+		return s.Status == status
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for service %s to be %s: %w", serviceID, status, err)
+	}
+
+	return service, nil
+}
+
+// WaitUntilDeleted polls a service until it is not found anymore.
+func (h *ServiceHandler) WaitUntilDeleted(projectID, serviceID string, opts ...WaitOption) error {
+	return h.WaitUntilDeletedCtx(context.Background(), projectID, serviceID, opts...)
+}
+
+// WaitUntilDeletedCtx is like WaitUntilDeleted but uses ctx for the underlying HTTP requests.
+func (h *ServiceHandler) WaitUntilDeletedCtx(ctx context.Context, projectID, serviceID string, opts ...WaitOption) (err error) {
+	ctx, span := h.client.startOperation(ctx, "Service.WaitUntilDeleted")
+	defer span.end(&err)
+
+	err = poll(ctx, newWaitOptions(opts), func(ctx context.Context) (bool, WaitProgress, error) {
+		service, err := h.GetCtx(ctx, projectID, serviceID, WithoutEnrichment())
+		if errors.Is(err, ErrNotFound) {
+			return true, WaitProgress{Status: ServiceStatusDeleting}, nil
+		}
+		if err != nil {
+			return false, WaitProgress{}, err
+		}
+
+		return false, WaitProgress{Status: service.Status, DeploymentStatus: service.DeploymentStatus}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for service %s to be deleted: %w", serviceID, err)
+	}
+
+	return nil
+}
+
+// waitFor polls a service until cond returns true and returns the last polled service.
+func (h *ServiceHandler) waitFor(ctx context.Context, projectID, serviceID string, opts []WaitOption, cond func(*Service) bool) (*Service, error) {
+	var service *Service
+	err := poll(ctx, newWaitOptions(opts), func(ctx context.Context) (bool, WaitProgress, error) {
+		s, err := h.GetCtx(ctx, projectID, serviceID, WithoutEnrichment())
+		if err != nil {
+			return false, WaitProgress{}, err
+		}
+		service = s
+
+		return cond(s), WaitProgress{Status: s.Status, DeploymentStatus: s.DeploymentStatus}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// WaitUntilDeployed polls a load balancer until its deployment status is
+// LoadBalancerDeploymentStatusDeployed and returns it.
+func (h *LoadBalancerHandler) WaitUntilDeployed(projectID, loadBalancerID string, opts ...WaitOption) (*LoadBalancer, error) {
+	return h.WaitUntilDeployedCtx(context.Background(), projectID, loadBalancerID, opts...)
+}
+
+// WaitUntilDeployedCtx is like WaitUntilDeployed but uses ctx for the underlying HTTP requests.
+func (h *LoadBalancerHandler) WaitUntilDeployedCtx(ctx context.Context, projectID, loadBalancerID string, opts ...WaitOption) (_ *LoadBalancer, err error) {
+	ctx, span := h.client.startOperation(ctx, "LoadBalancer.WaitUntilDeployed")
+	defer span.end(&err)
+
+	loadBalancer, err := h.waitFor(ctx, projectID, loadBalancerID, opts, func(lb *LoadBalancer) bool {
+		return lb.DeploymentStatus == LoadBalancerDeploymentStatusDeployed
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for load balancer %s to be deployed: %w", loadBalancerID, err)
+	}
+
+	return loadBalancer, nil
+}
+
+// WaitUntilConfigApplied polls a load balancer until it is deployed and its
+// config matches req, typically after UpdateConfig, and returns it.
+// Lists of domains, target services and removed headers are compared
+// regardless of their order.
+func (h *LoadBalancerHandler) WaitUntilConfigApplied(projectID, loadBalancerID string, req UpdateLoadBalancerConfigRequest, opts ...WaitOption) (*LoadBalancer, error) {
+	return h.WaitUntilConfigAppliedCtx(context.Background(), projectID, loadBalancerID, req, opts...)
+}
+
+// WaitUntilConfigAppliedCtx is like WaitUntilConfigApplied but uses ctx for the underlying HTTP requests.
+func (h *LoadBalancerHandler) WaitUntilConfigAppliedCtx(ctx context.Context, projectID, loadBalancerID string, req UpdateLoadBalancerConfigRequest, opts ...WaitOption) (_ *LoadBalancer, err error) {
+	ctx, span := h.client.startOperation(ctx, "LoadBalancer.WaitUntilConfigApplied")
+	defer span.end(&err)
+
+	loadBalancer, err := h.waitFor(ctx, projectID, loadBalancerID, opts, func(lb *LoadBalancer) bool {
+		return lb.DeploymentStatus == LoadBalancerDeploymentStatusDeployed && lb.Config.matches(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for load balancer %s config to be applied: %w", loadBalancerID, err)
+	}
+
+	return loadBalancer, nil
+}
+
+// waitFor polls a load balancer until cond returns true and returns the last polled load balancer.
+func (h *LoadBalancerHandler) waitFor(ctx context.Context, projectID, loadBalancerID string, opts []WaitOption, cond func(*LoadBalancer) bool) (*LoadBalancer, error) {
+	var loadBalancer *LoadBalancer
+	err := poll(ctx, newWaitOptions(opts), func(ctx context.Context) (bool, WaitProgress, error) {
+		lb, err := h.GetCtx(ctx, projectID, loadBalancerID)
+		if err != nil {
+			return false, WaitProgress{}, err
+		}
+		loadBalancer = lb
+
+		return cond(lb), WaitProgress{DeploymentStatus: lb.DeploymentStatus}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+// matches reports whether the config is the one requested by req.
+func (c LoadBalancerConfig) matches(req UpdateLoadBalancerConfigRequest) bool {
+	return c.HostHeader == req.HostHeader &&
+		c.IsAccessLogsEnabled == req.IsAccessLogsEnabled &&
+		c.IsForceHTTPSEnabled == req.IsForceHTTPSEnabled &&
+		c.IPRateLimit == req.IPRateLimit &&
+		c.IsIPRateLimitEnabled == req.IsIPRateLimitEnabled &&
+		c.OutputCacheInSeconds == req.OutputCacheInSeconds &&
+		c.IsStickySessionEnabled == req.IsStickySessionEnabled &&
+		c.IsProxyProtocolEnabled == req.IsProxyProtocolEnabled &&
+		sameStrings(c.SSLDomains, req.SSLDomains) &&
+		slices.Equal(c.ForwardRules, req.ForwardRules) &&
+		slices.Equal(c.OutputHeaders, req.OutputHeaders) &&
+		sameStrings(c.TargetServices, req.TargetServices) &&
+		sameStrings(c.RemoveResponseHeaders, req.RemoveResponseHeaders)
+}
+
+// sameStrings reports whether a and b hold the same strings in any order,
+// nil and empty slices being equal.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
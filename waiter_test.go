@@ -0,0 +1,102 @@
+package elestio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupWaiterTestCase serves the given service details, one per poll,
+// the last one being repeated.
+func setupWaiterTestCase(t *testing.T, details ...string) (*Client, *atomic.Int32) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := min(int(polls.Add(1)), len(details)) - 1
+		_, _ = fmt.Fprintf(w, `{"status":"OK","serviceInfos":[%s]}`, details[i])
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewUnsignedClient(WithBaseURL(srv.URL)), &polls
+}
+
+func TestServiceHandler_WaitUntilDeployed(t *testing.T) {
+	c, polls := setupWaiterTestCase(t,
+		`{"vmID":"1","deploymentStatus":"IN PROGRESS"}`,
+		`{"vmID":"1","deploymentStatus":"IN PROGRESS"}`,
+		`{"vmID":"1","deploymentStatus":"Deployed","status":"running"}`,
+	)
+
+	var progress []WaitProgress
+	service, err := c.Service.WaitUntilDeployed("1", "1",
+		WithPollInterval(time.Millisecond),
+		WithProgress(func(p WaitProgress) { progress = append(progress, p) }),
+	)
+	require.NoError(t, err)
+	require.Equal(t, ServiceDeploymentStatusDeployed, service.DeploymentStatus)
+	require.Equal(t, int32(3), polls.Load())
+	require.Len(t, progress, 3)
+	require.Equal(t, 3, progress[2].Attempt)
+	require.Equal(t, ServiceStatusRunning, progress[2].Status)
+}
+
+func TestServiceHandler_WaitUntilStatus_Timeout(t *testing.T) {
+	c, _ := setupWaiterTestCase(t, `{"vmID":"1","status":"running"}`)
+
+	_, err := c.Service.WaitUntilStatusCtx(context.Background(), "1", "1", ServiceStatusStopped,
+		WithPollInterval(time.Millisecond),
+		WithWaitTimeout(20*time.Millisecond),
+	)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServiceHandler_WaitUntilDeleted(t *testing.T) {
+	c, polls := setupWaiterTestCase(t, `{"vmID":"1","status":"deleting"}`, ``)
+
+	err := c.Service.WaitUntilDeleted("1", "1", WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), polls.Load())
+}
+
+func TestWithPollInterval_NonPositive(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		o := newWaitOptions([]WaitOption{WithPollInterval(d)})
+		require.Equal(t, 10*time.Second, o.interval, "expected default interval for %s", d)
+	}
+}
+
+func TestLoadBalancerHandler_WaitUntilConfigApplied(t *testing.T) {
+	configs := []string{
+		`{"hostHeader":"old","sslDomains":["a.com"]}`,
+		`{"hostHeader":"new","sslDomains":["b.com","a.com"],"forwardingRules":[{"protocol":"HTTPS","listeningPort":"443","targetProtocol":"HTTP","targetPort":"80"}]}`,
+	}
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/servers/getServerDetails":
+			_, _ = fmt.Fprint(w, `{"status":"OK","serviceInfos":[{"deploymentStatus":"Deployed"}]}`)
+		case "/api/loadBalancer/getLBDetails":
+			i := min(int(polls.Add(1)), len(configs)) - 1
+			_, _ = fmt.Fprintf(w, `{"status":"OK","data":%s}`, configs[i])
+		}
+	}))
+	defer srv.Close()
+	c := NewUnsignedClient(WithBaseURL(srv.URL))
+
+	lb, err := c.LoadBalancer.WaitUntilConfigApplied("1", "1", UpdateLoadBalancerConfigRequest{
+		HostHeader: "new",
+		SSLDomains: []string{"a.com", "b.com"},
+		ForwardRules: []LoadBalancerConfigForwardRule{
+			{Protocol: "HTTPS", Port: "443", TargetProtocol: "HTTP", TargetPort: "80"},
+		},
+		OutputHeaders: []LoadBalancerConfigOutputHeader{},
+	}, WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, "new", lb.Config.HostHeader)
+	require.Equal(t, int32(2), polls.Load())
+}